@@ -0,0 +1,202 @@
+package lkdp
+
+// 오전/오후가 모호한 시간의 해석
+//
+// eg: '3시' => 03:00 또는 15:00
+
+import (
+	"sort"
+	"time"
+)
+
+// AmbiguityPolicy ranks the candidates of an ambiguous time (eg: '3시' => 03:00, 15:00)
+//
+// without a policy (default), ambiguous times are kept as they are (with `Hms.Ambiguous` = true)
+type AmbiguityPolicy interface {
+	// Rank returns given candidates (on the matched or reference date) in the order of preference
+	Rank(candidates []time.Time, now time.Time) []time.Time
+}
+
+// AmbiguityPolicyFunc is a function which implements AmbiguityPolicy interface
+type AmbiguityPolicyFunc func(candidates []time.Time, now time.Time) []time.Time
+
+// Rank returns given candidates in the order of preference
+func (f AmbiguityPolicyFunc) Rank(candidates []time.Time, now time.Time) []time.Time {
+	return f(candidates, now)
+}
+
+// policies for ambiguous times
+var (
+	// PreferBusinessHours prefers candidates in business hours (07:00 ~ 18:59, eg: '3시' => 15:00, '9시' => 09:00)
+	PreferBusinessHours AmbiguityPolicy = AmbiguityPolicyFunc(func(candidates []time.Time, now time.Time) []time.Time {
+		return rankBy(candidates, func(a, b time.Time) bool {
+			return isBusinessHour(a.Hour()) && !isBusinessHour(b.Hour())
+		})
+	})
+
+	// PreferAM prefers AM candidates
+	PreferAM AmbiguityPolicy = AmbiguityPolicyFunc(func(candidates []time.Time, now time.Time) []time.Time {
+		return rankBy(candidates, func(a, b time.Time) bool {
+			return a.Hour() < 12 && b.Hour() >= 12
+		})
+	})
+
+	// PreferPM prefers PM candidates
+	PreferPM AmbiguityPolicy = AmbiguityPolicyFunc(func(candidates []time.Time, now time.Time) []time.Time {
+		return rankBy(candidates, func(a, b time.Time) bool {
+			return a.Hour() >= 12 && b.Hour() < 12
+		})
+	})
+)
+
+// TenseAwarePolicy is an AmbiguityPolicy which also takes the tense around the time into account
+type TenseAwarePolicy interface {
+	AmbiguityPolicy
+
+	// RankByTense returns given candidates in the order of preference, for given tense
+	RankByTense(candidates []time.Time, now time.Time, tense Tense) []time.Time
+}
+
+// PreferFuture prefers the earliest candidate after the reference time
+// (or the latest one if all of them are in the past),
+// and the latest candidate before the reference time in past-tense sentences (detected with WithTenseDetector, eg: '3시에 만났다')
+var PreferFuture AmbiguityPolicy = preferFuture{}
+
+// policy which prefers future candidates
+type preferFuture struct{}
+
+// Rank returns given candidates in the order of preference
+func (p preferFuture) Rank(candidates []time.Time, now time.Time) []time.Time {
+	return p.RankByTense(candidates, now, TenseUnknown)
+}
+
+// RankByTense returns given candidates in the order of preference, for given tense
+func (p preferFuture) RankByTense(candidates []time.Time, now time.Time, tense Tense) []time.Time {
+	if tense == TensePast {
+		return rankBy(candidates, func(a, b time.Time) bool {
+			aPast, bPast := !a.After(now), !b.After(now)
+			if aPast != bPast {
+				return aPast
+			}
+			if aPast {
+				return a.After(b)
+			}
+			return a.Before(b)
+		})
+	}
+
+	return rankBy(candidates, func(a, b time.Time) bool {
+		aFuture, bFuture := !a.Before(now), !b.Before(now)
+		if aFuture != bFuture {
+			return aFuture
+		}
+		if aFuture {
+			return a.Before(b)
+		}
+		return a.After(b)
+	})
+}
+
+// RejectAmbiguous is a policy which rejects ambiguous times with ErrAmbiguous errors
+var RejectAmbiguous AmbiguityPolicy = rejectAmbiguous{}
+
+// policy which rejects ambiguous times
+type rejectAmbiguous struct{}
+
+// Rank returns given candidates as they are (not used)
+func (r rejectAmbiguous) Rank(candidates []time.Time, now time.Time) []time.Time {
+	return candidates
+}
+
+// returns a sorted copy of given times
+func rankBy(times []time.Time, less func(a, b time.Time) bool) []time.Time {
+	ranked := make([]time.Time, len(times))
+	copy(ranked, times)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return less(ranked[i], ranked[j])
+	})
+	return ranked
+}
+
+// check if given hour is in business hours
+func isBusinessHour(hour int) bool {
+	return hour >= 7 && hour < 19
+}
+
+// returns the candidates of given time (AM, then PM), or nil if it is not ambiguous
+//
+// (only 1 ~ 12 o'clock have candidates, eg: '0시' is always midnight)
+func (h Hms) candidates() []Hms {
+	if !h.Ambiguous || h.Hours < 1 || h.Hours > 12 {
+		return nil
+	}
+
+	am, pm := h, h
+	am.Hours, am.Ambiguous = h.Hours%12, false
+	pm.Hours, pm.Ambiguous = h.Hours%12+12, false
+	return []Hms{am, pm}
+}
+
+// resolve the ambiguous time of given match with the ambiguity policy, placing candidates on the dates from `dateOf`
+//
+// (without a policy, the time is kept ambiguous with its candidates in AM, PM order)
+func (e *extraction) disambiguate(m *TimeMatch, dateOf func(hms Hms) time.Time) {
+	candidates := m.Hms.candidates()
+	if candidates == nil {
+		return
+	}
+	m.Candidates = candidates
+	if e.ambiguity == nil {
+		return
+	}
+	if _, reject := e.ambiguity.(rejectAmbiguous); reject {
+		e.rejected = append(e.rejected, e.error(ErrAmbiguous, SubjectTime, m.Span, ""))
+		return
+	}
+
+	times := make([]time.Time, len(candidates))
+	for i, c := range candidates {
+		times[i] = e.combine(dateOf(c), c)
+	}
+
+	// map ranked times back to the candidates (ignoring unknown or duplicated ones)
+	ranked, used := []Hms{}, make([]bool, len(candidates))
+	var rankedTimes []time.Time
+	if policy, ok := e.ambiguity.(TenseAwarePolicy); ok {
+		rankedTimes = policy.RankByTense(times, e.now, m.Tense)
+	} else {
+		rankedTimes = e.ambiguity.Rank(times, e.now)
+	}
+	for _, t := range rankedTimes {
+		for i := range times {
+			if !used[i] && t.Equal(times[i]) {
+				ranked, used[i] = append(ranked, candidates[i]), true
+				break
+			}
+		}
+	}
+	for i, c := range candidates {
+		if !used[i] {
+			ranked = append(ranked, c)
+		}
+	}
+
+	e.debugPrint("ambiguity: resolved %02d:%02d:%02d as %02d:%02d:%02d", m.Hms.Hours, m.Hms.Minutes, m.Hms.Seconds, ranked[0].Hours, ranked[0].Minutes, ranked[0].Seconds)
+
+	m.Hms, m.Candidates = ranked[0], ranked
+}
+
+// returns the date/time candidates of given time match on the dates from `dateOf`, or nil if it is not ambiguous
+func (e *extraction) dateTimeCandidates(m *TimeMatch, dateOf func(hms Hms) time.Time) (candidates []time.Time) {
+	for _, c := range m.Candidates {
+		candidates = append(candidates, e.combine(dateOf(c), c))
+	}
+	return candidates
+}
+
+// returns a function which places all times on given date
+func onDate(date time.Time) func(hms Hms) time.Time {
+	return func(hms Hms) time.Time {
+		return date
+	}
+}
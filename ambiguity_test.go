@@ -0,0 +1,81 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAmbiguityPolicies(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 10, 0, 0, 0, loc)
+
+	for _, test := range []struct {
+		str      string
+		policy   AmbiguityPolicy
+		expected []string // ranked candidates
+	}{
+		{`3시에 보자`, nil, []string{`2021-03-01 03:00`, `2021-03-01 15:00`}},
+		{`3시에 보자`, PreferFuture, []string{`2021-03-01 15:00`, `2021-03-01 03:00`}},
+		{`11시에 보자`, PreferFuture, []string{`2021-03-01 11:00`, `2021-03-01 23:00`}},
+		{`내일 9시`, PreferFuture, []string{`2021-03-02 09:00`, `2021-03-02 21:00`}},
+		{`어제 9시`, PreferFuture, []string{`2021-02-28 21:00`, `2021-02-28 09:00`}},
+		{`2시 회의`, PreferBusinessHours, []string{`2021-03-01 14:00`, `2021-03-01 02:00`}},
+		{`8시 회의`, PreferBusinessHours, []string{`2021-03-01 08:00`, `2021-03-01 20:00`}},
+		{`5시`, PreferAM, []string{`2021-03-01 05:00`, `2021-03-01 17:00`}},
+		{`5시`, PreferPM, []string{`2021-03-01 17:00`, `2021-03-01 05:00`}},
+	} {
+		matches, err := ExtractDateTimeMatches(test.str, true, WithReferenceTime(ref), WithAmbiguityPolicy(test.policy))
+		if err != nil {
+			t.Errorf("ExtractDateTimeMatches failed with string: '%s' (error: %s)", test.str, err)
+			continue
+		}
+
+		m := matches[0]
+		var candidates []string
+		for _, c := range m.Candidates {
+			candidates = append(candidates, c.Format("2006-01-02 15:04"))
+		}
+		if len(candidates) != len(test.expected) || candidates[0] != test.expected[0] || candidates[1] != test.expected[1] ||
+			m.DateTime.Format("2006-01-02 15:04") != test.expected[0] {
+			t.Errorf("ExtractDateTimeMatches extracted: %s (candidates: %v) from string: '%s' (expected: %v)", m.DateTime.Format("2006-01-02 15:04"), candidates, test.str, test.expected)
+		}
+		if m.TimeMatch.Hms.Ambiguous != (test.policy == nil) || len(m.TimeMatch.Candidates) != 2 {
+			t.Errorf("ExtractDateTimeMatches extracted time: %+v from string: '%s'", *m.TimeMatch, test.str)
+		}
+	}
+
+	// times
+	if hms, err := ExtractTime(`3시`, false, WithReferenceTime(ref), WithAmbiguityPolicy(PreferBusinessHours)); err != nil || hms.Hours != 15 || hms.Ambiguous {
+		t.Errorf("ExtractTime returned: %+v (error: %v)", hms, err)
+	}
+	if matches, err := ExtractTimeMatches(`오후 3시`, false, WithAmbiguityPolicy(PreferAM)); err != nil || matches[0].Hms.Hours != 15 || matches[0].Candidates != nil {
+		t.Errorf("ExtractTimeMatches returned: %+v (error: %v)", matches, err)
+	}
+
+	// times are resolved on the dates right before them, as with dates and times
+	for _, str := range []string{`내일 3시`, `어제 9시`, `3시`} {
+		hms, err := ExtractTime(str, false, WithReferenceTime(ref), WithAmbiguityPolicy(PreferFuture))
+		datetime, err2 := ExtractDateTime(str, false, WithReferenceTime(ref), WithAmbiguityPolicy(PreferFuture))
+		if err != nil || err2 != nil || hms.Hours != datetime.Hour() {
+			t.Errorf("ExtractTime returned: %+v, while ExtractDateTime returned: %v from string: '%s' (errors: %v, %v)", hms, datetime, str, err, err2)
+		}
+	}
+
+	// no candidates for midnight
+	if matches, err := ExtractTimeMatches(`0시`, false, WithReferenceTime(ref), WithAmbiguityPolicy(PreferFuture)); err != nil || matches[0].Hms.Hours != 0 || matches[0].Candidates != nil {
+		t.Errorf("ExtractTimeMatches returned: %+v (error: %v)", matches, err)
+	}
+
+	// ranges: PM of the start is inherited regardless of the policy
+	if r, err := ExtractRange(`오후 3시부터 5시까지`, true, WithReferenceTime(ref), WithAmbiguityPolicy(PreferAM)); err != nil || r.To.Hour() != 17 {
+		t.Errorf("ExtractRange returned: %+v (error: %v)", r, err)
+	}
+
+	// custom policy
+	latest := AmbiguityPolicyFunc(func(candidates []time.Time, now time.Time) []time.Time {
+		return []time.Time{candidates[1]}
+	})
+	if hms, err := ExtractTime(`4시`, false, WithReferenceTime(ref), WithAmbiguityPolicy(latest)); err != nil || hms.Hours != 16 {
+		t.Errorf("ExtractTime with custom policy returned: %+v (error: %v)", hms, err)
+	}
+}
@@ -0,0 +1,108 @@
+package lkdp
+
+// 앞선 표현으로부터의 문맥 상속
+//
+// eg: '2020년 3월 5일, 6일' => 2020년 3월 6일, '12월 12일부터 다음 해 6월 2일까지' => (12월 12일의 다음 해) 6월 2일,
+// '어제 오후 1시와 3시 사이' => 어제 15시
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// the next year of the preceding date (eg: '다음 해', '이듬해')
+var contextNextYear = fmt.Sprintf(`(?:%s\s*%s|이듬해)`, ExpressionNext1, ExpressionYear3)
+
+// text right before a date in the next year of the preceding one (eg: '12월 12일부터 다음 해 6월 2일까지')
+var contextNextYearRe = regexp.MustCompile(contextNextYear + `\s*(?:의|에)?\s*$`)
+
+// check if given spans are in the same sentence
+func (e *extraction) sameSentence(prev, next Span) bool {
+	return prev.End <= next.Start && !sentenceEndRe.MatchString(e.str[prev.End:next.Start])
+}
+
+// fill missing year/month of given date match with the ones of the preceding date match (in the same sentence)
+//
+// (missing parts are kept as they are for ranges, and marked as inherited for the following ones)
+func (e *extraction) inheritDate(prev, m *DateMatch) {
+	if prev == nil || !e.sameSentence(prev.Span, m.Span) {
+		return
+	}
+
+	inherited, parts := e.inheritedDateOf(prev, m, false)
+	if parts == 0 {
+		return
+	}
+
+	e.debugPrint("context: inherited %s from '%s' as %s", m.Text, prev.Text, inherited.Date.Format("2006-01-02"))
+
+	*m = inherited
+	m.inherited = parts
+}
+
+// returns given date match with its missing year/month filled with the ones of the preceding date match,
+// and the filled parts (zero if nothing can be inherited)
+//
+// with `resolved`, the missing parts of the preceding one are also inherited as they were resolved
+// (eg: for the ends of ranges, month of '5일~7일', year of '12월 12일부터 6월 2일까지')
+func (e *extraction) inheritedDateOf(prev, m *DateMatch, resolved bool) (inherited DateMatch, parts dateParts) {
+	if m.missing&dateYear == 0 {
+		return *m, 0
+	}
+
+	// parts of the preceding one which were given or inherited
+	given := prev.inherited | ^prev.missing
+	if resolved {
+		given = dateYear | dateMonth
+	}
+
+	ok := false
+	switch {
+	case contextNextYearRe.MatchString(e.str[prev.End:m.Start]): // '다음 해 6월 2일'
+		if prev.Date.Year() <= 0 {
+			return *m, 0
+		}
+		inherited, ok = m.inYear(prev.Date.Year() + 1)
+		parts = dateYear
+	case m.missing&dateMonth != 0 && given&dateMonth != 0: // '3월 5일, 6일'
+		date := time.Date(prev.Date.Year(), prev.Date.Month(), m.Date.Day(), 0, 0, 0, 0, e.location)
+		inherited, ok = m.movedTo(date), date.Day() == m.Date.Day()
+		parts = dateYear | dateMonth
+	case given&dateYear != 0: // '2020년 3월 5일, 4월 2일'
+		inherited, ok = m.inYear(prev.Date.Year())
+		parts = dateYear
+	}
+	if !ok { // not in the month (eg: '1월 31일, 2월 31일')
+		return *m, 0
+	}
+
+	return inherited, parts
+}
+
+// resolve the ambiguous AM/PM of given time match with the preceding time match (in the same sentence)
+//
+// (the earliest candidate which does not precede the preceding time, eg: '오후 1시와 3시' => 15시, '오전 9시, 11시' => 11시)
+func (e *extraction) inheritTime(prev, m *TimeMatch) {
+	if prev == nil || !m.Hms.Ambiguous || prev.Hms.Ambiguous || prev.Candidates != nil || !e.sameSentence(prev.Span, m.Span) {
+		return
+	}
+
+	for _, c := range m.Hms.candidates() {
+		if c.Hours >= prev.Hms.Hours {
+			e.debugPrint("context: inherited %s from '%s' as %02d:%02d:%02d", m.Text, prev.Text, c.Hours, c.Minutes, c.Seconds)
+
+			m.Hms = c
+			return
+		}
+	}
+}
+
+// returns the date of the preceding date/time match for the time without a date (in the same sentence),
+// or false if there is none (eg: 어제 of '어제 오후 1시와 3시 사이', 내일 of '내일 9시, 11시, 1시')
+func (e *extraction) inheritedDate(prev *DateTimeMatch, m *TimeMatch) (time.Time, bool) {
+	if prev == nil || (prev.DateMatch == nil && !prev.anchored) || m.Rule == RuleTimeRel1 || !e.sameSentence(prev.Span, m.Span) {
+		return time.Time{}, false
+	}
+	return time.Date(prev.DateTime.Year(), prev.DateTime.Month(), prev.DateTime.Day(), 0, 0, 0, 0, e.location), true
+}
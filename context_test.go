@@ -0,0 +1,63 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInheritContext(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 10, 0, 0, 0, loc)
+
+	// dates with times
+	for str, expected := range map[string][]string{
+		`12월 12일부터 다음 해 6월 2일까지`:    {`2021-12-12 00:00`, `2022-06-02 00:00`},
+		`어제 오후 1시와 3시 사이`:           {`2021-02-28 13:00`, `2021-02-28 15:00`},
+		`어제 오후 1시에 만나고 3시에 헤어졌다`:    {`2021-02-28 13:00`, `2021-02-28 15:00`},
+		`2020년 3월 5일에 갔고 4월 2일에 왔다`: {`2020-03-05 00:00`, `2020-04-02 00:00`},
+		`내년 3월 5일, 6일, 7일`:          {`2022-03-05 00:00`, `2022-03-06 00:00`, `2022-03-07 00:00`},
+		`내일 오전 9시, 11시, 1시`:         {`2021-03-02 09:00`, `2021-03-02 11:00`, `2021-03-02 13:00`},
+		`어제 만났다. 3시에 보자`:            {`2021-02-28 00:00`, `2021-03-01 03:00`}, // (in another sentence)
+		`어제 오후 1시, 2시간 뒤`:           {`2021-02-28 13:00`, `2021-03-01 12:00`}, // (relative time)
+		`1월 31일, 2월 31일`:            {`2021-01-31 00:00`, `2021-03-03 00:00`}, // (not inherited: normalized)
+	} {
+		if matches, err := ExtractDateTimeMatches(str, true, WithReferenceTime(ref)); err == nil {
+			datetimes := []string{}
+			for _, m := range matches {
+				datetimes = append(datetimes, m.DateTime.Format("2006-01-02 15:04"))
+			}
+			if len(datetimes) != len(expected) {
+				t.Errorf("ExtractDateTimeMatches extracted: %v from string: '%s' (expected: %v)", datetimes, str, expected)
+				continue
+			}
+			for i := range expected {
+				if datetimes[i] != expected[i] {
+					t.Errorf("ExtractDateTimeMatches extracted: %v from string: '%s' (expected: %v)", datetimes, str, expected)
+					break
+				}
+			}
+		} else {
+			t.Errorf("ExtractDateTimeMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// dates only
+	if matches, err := ExtractDateMatches(`2020년 3월 5일, 6일`, true, WithReferenceTime(ref)); err != nil || len(matches) != 2 || matches[1].Date.Format("2006-01-02") != `2020-03-06` {
+		t.Errorf("ExtractDateMatches extracted unexpected matches: %+v (error: %v)", matches, err)
+	}
+
+	// times only
+	if matches, err := ExtractTimeMatches(`오후 1시와 3시 사이`, false, WithReferenceTime(ref)); err != nil || len(matches) != 2 || matches[1].Hms.Hours != 15 || matches[1].Hms.Ambiguous {
+		t.Errorf("ExtractTimeMatches extracted unexpected matches: %+v (error: %v)", matches, err)
+	}
+
+	// not rejected when inherited
+	if _, err := ExtractTimeMatches(`오후 1시와 3시 사이`, false, WithReferenceTime(ref), WithAmbiguityPolicy(RejectAmbiguous)); err != nil {
+		t.Errorf("ExtractTimeMatches should not fail (error: %v)", err)
+	}
+
+	// ranges: missing parts are still inherited from the start (with the year after it)
+	if r, err := ExtractRange(`2020년 12월 12일부터 6월 2일까지`, true, WithReferenceTime(ref)); err != nil || r.To.Format("2006-01-02") != `2021-06-02` {
+		t.Errorf("ExtractRange extracted unexpected range: %+v (error: %v)", r, err)
+	}
+}
@@ -0,0 +1,184 @@
+package lkdp
+
+import (
+	"regexp"
+	"time"
+)
+
+// text allowed between a date and a time expression (eg: '내일 오후 3시', '3월 5일에 2시', '내일, 3시')
+var dateTimeGapRe = regexp.MustCompile(`^[\s,]*(에|의|에는)?[\s,]*$`)
+
+// ExtractDateTimeMatches extracts all dates with times and their positions from given string
+//
+// each date expression is paired with the time expression right after it
+// (eg: '내일 오후 3시 반'), and changed days of relative times (eg: '3시간 뒤') are applied.
+// times without dates are placed on the date of the preceding expression in the same sentence (eg: '어제 오후 1시와 3시'),
+// the reference date, or the date of their nearest occurrences with ResolveNextOccurrence,
+// and dates without times are placed at 00:00:00.
+//
+// returned matches are ordered by their positions
+//
+// returns `nil` matches on error
+func (p *Parser) ExtractDateTimeMatches(str string, ifEmptyFillAsNow bool) (matches []DateTimeMatch, err error) {
+	e := p.newExtraction(str, ifEmptyFillAsNow)
+	matches = e.dateTimes()
+
+	// invalid dates/times in strict mode
+	if err = e.rejection(); err != nil {
+		return nil, err
+	}
+
+	if len(matches) <= 0 {
+		return nil, e.noMatch(SubjectDateTime)
+	}
+
+	return matches, nil
+}
+
+// ExtractDateTimes extracts all dates with times from given string
+//
+// (when the same text is matched multiple times, the first one is used)
+//
+// returns `nil` datetimes on error
+//
+// 주어진 한글 string으로부터 날짜+시간 추출
+func (p *Parser) ExtractDateTimes(str string, ifEmptyFillAsNow bool) (datetimes map[string]time.Time, err error) {
+	var matches []DateTimeMatch
+	if matches, err = p.ExtractDateTimeMatches(str, ifEmptyFillAsNow); err != nil {
+		return nil, err
+	}
+
+	datetimes = map[string]time.Time{}
+	for _, m := range matches {
+		if _, exists := datetimes[m.Text]; !exists {
+			datetimes[m.Text] = m.DateTime
+		}
+	}
+
+	return datetimes, nil
+}
+
+// ExtractDateTime extracts date with time from given string
+//
+// 주어진 한글 string으로부터 패턴에 가장 먼저 맞는 날짜+시간값 추출
+func (p *Parser) ExtractDateTime(str string, ifEmptyFillAsNow bool) (datetime time.Time, err error) {
+	var matches []DateTimeMatch
+	if matches, err = p.ExtractDateTimeMatches(str, ifEmptyFillAsNow); err != nil {
+		return time.Time{}, err
+	}
+
+	// the left-most(with the least index) matched datetime
+	return matches[0].DateTime, nil
+}
+
+// extract all dates with times
+func (e *extraction) dateTimes() (matches []DateTimeMatch) {
+	// resolve overlaps between dates and times
+	dates, dateCandidates := e.dateCandidates(0)
+	times, timeCandidates := e.timeCandidates(len(e.rules.dates))
+	for i := range timeCandidates {
+		timeCandidates[i].index += len(dates)
+	}
+	selected := resolveOverlaps(append(dateCandidates, timeCandidates...))
+
+	for i := 0; i < len(selected); i++ {
+		// missing parts are inherited from the preceding one (eg: '3월 5일, 6일', '어제 오후 1시와 3시')
+		var prev *DateTimeMatch
+		var prevDate *DateMatch
+		var prevTime *TimeMatch
+		if len(matches) > 0 {
+			prev = &matches[len(matches)-1]
+			prevDate, prevTime = prev.DateMatch, prev.TimeMatch
+		}
+
+		if c := selected[i]; c.index < len(dates) {
+			date := dates[c.index]
+			e.inheritDate(prevDate, &date)
+
+			// pair with the time right after it
+			if i+1 < len(selected) && selected[i+1].index >= len(dates) && dateTimeGapRe.MatchString(e.str[date.End:selected[i+1].Start]) {
+				t := times[selected[i+1].index-len(dates)]
+				e.inheritTime(prevTime, &t)
+				e.disambiguate(&t, onDate(date.Date))
+				i++
+
+				span := newSpan(e.str, date.Start, t.End)
+				matches = append(matches, DateTimeMatch{
+					Span:       span,
+					DateMatch:  &date,
+					TimeMatch:  &t,
+					DateTime:   e.combine(date.Date, t.Hms),
+					Candidates: e.dateTimeCandidates(&t, onDate(date.Date)),
+					Tense:      e.tense(span),
+					now:        e.now,
+				})
+			} else {
+				matches = append(matches, DateTimeMatch{
+					Span:      date.Span,
+					DateMatch: &date,
+					DateTime:  date.Date,
+					Tense:     date.Tense,
+					now:       e.now,
+				})
+			}
+		} else {
+			t := times[c.index-len(dates)]
+			e.inheritTime(prevTime, &t)
+
+			// place it on the date of the preceding one, the reference date, or the date of its nearest occurrence
+			dateOf := onDate(e.now)
+			date, anchored := e.inheritedDate(prev, &t)
+			nearest := !anchored && e.resolution == ResolveNextOccurrence && t.Rule != RuleTimeRel1
+			if anchored {
+				dateOf = onDate(date)
+			} else if nearest {
+				past := t.Tense == TensePast
+				dateOf = func(hms Hms) time.Time {
+					return e.nearestDateOfTime(hms, past)
+				}
+			}
+			e.disambiguate(&t, dateOf)
+
+			datetime, candidates := e.combine(dateOf(t.Hms), t.Hms), e.dateTimeCandidates(&t, dateOf)
+			if nearest && e.ambiguity == nil && len(candidates) > 0 { // (not resolved by any policy: the nearest of AM and PM)
+				candidates = e.nearestCandidates(candidates, t.Tense)
+				datetime = candidates[0]
+			}
+
+			matches = append(matches, DateTimeMatch{
+				Span:       t.Span,
+				TimeMatch:  &t,
+				DateTime:   datetime,
+				Candidates: candidates,
+				Tense:      t.Tense,
+				anchored:   anchored,
+				now:        e.now,
+			})
+		}
+	}
+
+	return matches
+}
+
+// returns the date of the date match right before given time match (eg: 내일 of '내일 3시'), or false if there is none
+func (e *extraction) pairedDate(dates []DateMatch, m *TimeMatch) (time.Time, bool) {
+	for _, date := range dates {
+		if date.End <= m.Start && dateTimeGapRe.MatchString(e.str[date.End:m.Start]) {
+			return date.Date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// combine date and time, applying the number of changed days
+func (e *extraction) combine(date time.Time, hms Hms) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day()+hms.NumDaysChanged, hms.Hours, hms.Minutes, hms.Seconds, 0, e.location)
+}
+
+// number of days between dates of given times
+func daysBetween(from, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
@@ -0,0 +1,71 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExtractDateTime(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2020, 11, 10, 22, 15, 0, 0, loc)
+
+	for str, expected := range map[string]string{
+		`내일 오후 3시 반에 회의`:       `2020-11-11 15:30:00`,
+		`3월 5일에 오전 10시 20분 출발`: `2020-03-05 10:20:00`,
+		`3시간 뒤에 다시 연락할게요`:      `2020-11-11 01:15:00`,
+		`오후 7시 30분에 봐요`:        `2020-11-10 19:30:00`,
+		`모레 만나요`:               `2020-11-12 00:00:00`,
+		`내일 오후 12시 30분에 점심`:    `2020-11-11 12:30:00`,
+		`내일 오전 12시 10분에 출발`:    `2020-11-11 00:10:00`,
+	} {
+		if d, err := ExtractDateTime(str, true, WithReferenceTime(ref)); err == nil {
+			if d.Format("2006-01-02 15:04:05") != expected {
+				t.Errorf("ExtractDateTime extracted datetime: %s from string: '%s' (expected: %s)", d.Format("2006-01-02 15:04:05"), str, expected)
+			}
+			if d.Location().String() != loc.String() {
+				t.Errorf("ExtractDateTime extracted datetime with wrong location: %s", d.Location())
+			}
+		} else {
+			t.Errorf("ExtractDateTime failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	if _, err := ExtractDateTime(`아무 것도 없음`, false); err == nil {
+		t.Errorf("ExtractDateTime should fail with string without any date or time")
+	}
+}
+
+func TestExtractDateTimes(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2020, 11, 10, 9, 0, 0, 0, loc)
+
+	str := `어제 오후 1시 반에 만났고, 모레 14:00에 다시 보기로 했다`
+	if dts, err := ExtractDateTimes(str, false, WithReferenceTime(ref)); err == nil {
+		for m, expected := range map[string]string{
+			`어제 오후 1시 반`: `2020-11-09 13:30:00`,
+			`모레 14:00`:   `2020-11-12 14:00:00`,
+		} {
+			if d, exists := dts[m]; !exists || d.Format("2006-01-02 15:04:05") != expected {
+				t.Errorf("ExtractDateTimes extracted datetime: %s from match: '%s' (expected: %s, all: %v)", d.Format("2006-01-02 15:04:05"), m, expected, dts)
+			}
+		}
+	} else {
+		t.Errorf("ExtractDateTimes failed with string: '%s' (error: %s)", str, err)
+	}
+
+	// 오전/오후 12시 (and the time after it on the same date)
+	str = `내일 오후 12시에 점심, 모레 오전 12시에 출발`
+	if dts, err := ExtractDateTimes(str, false, WithReferenceTime(ref)); err == nil {
+		for m, expected := range map[string]string{
+			`내일 오후 12시`: `2020-11-11 12:00:00`,
+			`점심`:        `2020-11-11 12:00:00`,
+			`모레 오전 12시`: `2020-11-12 00:00:00`,
+		} {
+			if d, exists := dts[m]; !exists || d.Format("2006-01-02 15:04:05") != expected {
+				t.Errorf("ExtractDateTimes extracted datetime: %s from match: '%s' (expected: %s, all: %v)", d.Format("2006-01-02 15:04:05"), m, expected, dts)
+			}
+		}
+	} else {
+		t.Errorf("ExtractDateTimes failed with string: '%s' (error: %s)", str, err)
+	}
+}
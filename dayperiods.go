@@ -0,0 +1,128 @@
+package lkdp
+
+// 하루 중의 때를 나타내는 표현
+//
+// eg: '저녁 7시' => 19:00, '밤 12시' => 00:00 (다음 날), '내일 점심' => 12:00
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DayPeriod is a (vague) period of a day, like '아침' or '저녁'
+//
+// it disambiguates numeric hours right after it (eg: '저녁 7시' => 19:00),
+// and is used as a time by itself (eg: '내일 점심' => 12:00)
+type DayPeriod struct {
+	Name string
+
+	// range of hours [From, To) for disambiguating numeric hours (eg: 17, 22 for '저녁'),
+	// hours over 24 are of the next day (eg: 19, 29 for '밤'), and To = 0 for no range
+	From, To int
+
+	Default Hms // time when used by itself (eg: 12:00 for '점심')
+}
+
+// DefaultDayPeriods returns the default periods of a day
+func DefaultDayPeriods() []DayPeriod {
+	return []DayPeriod{
+		{Name: `새벽`, From: 0, To: 6, Default: Hms{Hours: 5}},
+		{Name: `아침`, From: 5, To: 11, Default: Hms{Hours: 8}},
+		{Name: ExpressionPeriodAM1, From: 0, To: 12, Default: Hms{Hours: 9}},
+		{Name: `점심`, From: 11, To: 15, Default: Hms{Hours: 12}},
+		{Name: `낮`, From: 10, To: 18, Default: Hms{Hours: 13}},
+		{Name: ExpressionPeriodPM1, From: 12, To: 24, Default: Hms{Hours: 15}},
+		{Name: `저녁`, From: 17, To: 22, Default: Hms{Hours: 19}},
+		{Name: `밤`, From: 19, To: 29, Default: Hms{Hours: 21}},
+		{Name: `정오`, Default: Hms{Hours: 12}},
+		{Name: `자정`, Default: Hms{NumDaysChanged: 1}},
+		{Name: `한밤중`, From: 22, To: 27, Default: Hms{NumDaysChanged: 1}},
+	}
+}
+
+// disambiguate given time with this period
+//
+// returns false if the time does not fit in this period
+func (d DayPeriod) apply(hms Hms) (Hms, bool) {
+	if d.To <= d.From { // no range
+		return hms, false
+	}
+
+	hours := []int{hms.Hours, hms.Hours + 24}
+	if hms.Ambiguous || hms.Hours == 12 { // '12시' can also be midnight (eg: '밤 12시')
+		hours = []int{hms.Hours % 12, hms.Hours%12 + 12, hms.Hours%12 + 24}
+	}
+	for _, hour := range hours {
+		if hour >= d.From && hour < d.To {
+			if hour >= 24 {
+				hms.NumDaysChanged++
+			}
+			hms.Hours, hms.Ambiguous = hour%24, false
+			return hms, true
+		}
+	}
+	return hms, false
+}
+
+// compiled periods of a day
+type dayPeriods struct {
+	periods  map[string]DayPeriod
+	re       *regexp.Regexp // period by itself (not in a word, eg: '낮잠', '군밤')
+	prefixRe *regexp.Regexp // period right before a time
+}
+
+// default periods of a day
+var defaultDayPeriods = newDayPeriods(DefaultDayPeriods())
+
+// compile given periods of a day
+func newDayPeriods(periods []DayPeriod) *dayPeriods {
+	compiled := &dayPeriods{periods: map[string]DayPeriod{}}
+	if len(periods) <= 0 {
+		return compiled
+	}
+
+	var names []string
+	for _, period := range periods {
+		compiled.periods[period.Name] = period
+		names = append(names, regexp.QuoteMeta(period.Name))
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+
+	// (the syllable right after the period, if not a particle, is captured for rejecting words like '낮잠', '아침밥')
+	compiled.re = regexp.MustCompile(fmt.Sprintf(`(?:^|[^가-힣])(%s)(?:%s|([가-힣]))?`, strings.Join(names, "|"), boundaryParticles))
+	compiled.prefixRe = regexp.MustCompile(fmt.Sprintf(`(?:^|[^가-힣])(%s)\s*$`, strings.Join(names, "|")))
+
+	return compiled
+}
+
+// returns the regular expression of periods of a day for the time rule
+func dayPeriodsRe(p *Parser) *regexp.Regexp {
+	return p.dayPeriods.re
+}
+
+// returns the period of a day which ends right before given offset of the normalized string, with its start offset
+func (e *extraction) dayPeriodBefore(end int) (period DayPeriod, start int, exists bool) {
+	if e.dayPeriods.prefixRe == nil {
+		return DayPeriod{}, 0, false
+	}
+
+	indices := e.dayPeriods.prefixRe.FindStringSubmatchIndex(e.normalized[:end])
+	if indices == nil {
+		return DayPeriod{}, 0, false
+	}
+	return e.dayPeriods.periods[e.normalized[indices[2]:indices[3]]], indices[2], true
+}
+
+// '점심', '저녁', '자정' 등 (시간 없이 쓰인 경우)
+func parseTimeDayPeriod1(e *extraction, slices []string) (Hms, bool) {
+	period, exists := e.dayPeriods.periods[slices[1]]
+	if !exists || slices[2] != "" { // in a word (eg: '낮잠', '가격이 낮다')
+		return Hms{}, false
+	}
+
+	return period.Default, true
+}
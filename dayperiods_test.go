@@ -0,0 +1,78 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayPeriods(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 9, 0, 0, 0, loc)
+
+	for str, expected := range map[string]struct {
+		text     string
+		datetime string
+	}{
+		`저녁 7시에 만나`: {`저녁 7시`, `2021-03-01 19:00`},
+		`아침 8시 반`:   {`아침 8시 반`, `2021-03-01 08:30`},
+		`낮 2시`:      {`낮 2시`, `2021-03-01 14:00`},
+		`밤 11시`:     {`밤 11시`, `2021-03-01 23:00`},
+		`밤 12시`:     {`밤 12시`, `2021-03-02 00:00`},
+		`밤 1시`:      {`밤 1시`, `2021-03-02 01:00`},
+		`새벽 3시`:     {`새벽 3시`, `2021-03-01 03:00`},
+		`점심 12시 반`:  {`점심 12시 반`, `2021-03-01 12:30`},
+		`내일 점심에 보자`: {`점심`, `2021-03-02 12:00`},
+		`모레 저녁`:     {`저녁`, `2021-03-03 19:00`},
+		`정오`:        {`정오`, `2021-03-01 12:00`},
+		`오늘 자정까지`:   {`자정`, `2021-03-02 00:00`},
+		`한밤중에`:      {`한밤중`, `2021-03-02 00:00`},
+		`오늘 저녁이야`:   {`저녁`, `2021-03-01 19:00`},
+		`저녁 일곱 시`:   {`저녁 일곱 시`, `2021-03-01 19:00`},
+		`저녁 3시간 후`:  {`3시간 후`, `2021-03-01 12:00`},
+		`아침 19시`:    {`19시`, `2021-03-01 19:00`},
+	} {
+		if matches, err := ExtractDateTimeMatches(str, true, WithReferenceTime(ref)); err == nil {
+			m := matches[len(matches)-1]
+			if m.TimeMatch == nil || m.TimeMatch.Text != expected.text || m.DateTime.Format("2006-01-02 15:04") != expected.datetime {
+				t.Errorf("ExtractDateTimeMatches extracted: %+v from string: '%s' (expected: %+v)", m, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateTimeMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// not in a word
+	for _, str := range []string{
+		`가격이 낮다`,
+		`낮잠 자자`,
+		`군밤 사 먹자`,
+		`밤새 일했다`,
+		`아침밥 먹었어`,
+	} {
+		if matches, err := ExtractTimeMatches(str, false, WithReferenceTime(ref)); err == nil {
+			t.Errorf("ExtractTimeMatches should fail with string: '%s' (extracted: %+v)", str, matches)
+		}
+	}
+}
+
+func TestWithDayPeriods(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 9, 0, 0, 0, loc)
+
+	// custom period
+	periods := append(DefaultDayPeriods(), DayPeriod{Name: `퇴근길`, From: 17, To: 21, Default: Hms{Hours: 18}})
+	if hms, err := ExtractTime(`퇴근길 7시`, false, WithReferenceTime(ref), WithDayPeriods(periods)); err != nil || hms.Hours != 19 {
+		t.Errorf("ExtractTime with custom day periods returned: %+v (error: %v)", hms, err)
+	}
+	if hms, err := ExtractTime(`퇴근길에`, false, WithReferenceTime(ref), WithDayPeriods(periods)); err != nil || hms.Hours != 18 {
+		t.Errorf("ExtractTime with custom day periods returned: %+v (error: %v)", hms, err)
+	}
+
+	// disabled
+	if _, err := ExtractTime(`점심에 보자`, false, WithReferenceTime(ref), WithDayPeriods(nil)); err == nil {
+		t.Errorf("ExtractTime should fail without day periods")
+	}
+	if hms, err := ExtractTime(`저녁 7시`, false, WithReferenceTime(ref), WithDayPeriods(nil)); err != nil || hms.Hours != 7 || !hms.Ambiguous {
+		t.Errorf("ExtractTime without day periods returned: %+v (error: %v)", hms, err)
+	}
+}
@@ -0,0 +1,258 @@
+package lkdp
+
+// 기간(길이) 표현
+//
+// eg: '2시간 30분 동안', '1년 6개월간', '사흘 동안', '반나절', '한 시간 반'
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// named durations
+var namedDurations = map[string]Duration{
+	`한나절`: {Time: 6 * time.Hour},
+	`반나절`: {Time: 3 * time.Hour},
+}
+
+// units which are not ambiguous with dates/times (eg: '3시간' vs. '3일', '5분')
+var unambiguousDurationUnits = []string{
+	ExpressionTimeHour1,
+	ExpressionMonth3,
+	ExpressionMonth4,
+	ExpressionYear3,
+	ExpressionWeek2,
+	ExpressionWeek1,
+}
+
+var durationRe, durationUnitRe *regexp.Regexp
+
+func init() {
+	units := strings.Join([]string{
+		ExpressionYear1,
+		ExpressionYear2,
+		ExpressionYear3,
+		ExpressionMonth3,
+		ExpressionMonth4,
+		ExpressionWeek2,
+		ExpressionWeek1,
+		ExpressionDay1,
+		ExpressionDay2,
+		dayCountUnit,
+		ExpressionTimeHour1,
+		ExpressionTimeMinute1,
+		ExpressionTimeSecond1,
+	}, "|")
+
+	durationUnitRe = regexp.MustCompile(fmt.Sprintf(`(\d+)\s*(%s|%s)`, units, ExpressionMonth1))
+	durationRe = regexp.MustCompile(fmt.Sprintf(`((?:\d+\s*(?:%s)\s*)+)(%s)?\s*(%s)?|(%s)`,
+		units,
+		ExpressionMinuteThirty,
+		strings.Join([]string{
+			`동안`,
+			`내내`,
+			`이내`,
+			`간`,
+			ExpressionBefore1,
+			ExpressionAfter1,
+			ExpressionAfter2,
+		}, "|"),
+		strings.Join([]string{
+			`한나절`,
+			`반나절`,
+		}, "|"),
+	))
+}
+
+// Duration is a length of time with calendar-aware components
+//
+// (eg: '1년 6개월' = {Years: 1, Months: 6}, '2시간 30분' = {Time: 2h30m})
+type Duration struct {
+	Years, Months, Days int // calendar components (weeks are counted as 7 days)
+
+	Time time.Duration // hours, minutes, and seconds
+}
+
+// TimeDuration returns the approximated time.Duration of this duration
+//
+// (1 year = 365 days, 1 month = 30 days, 1 day = 24 hours)
+func (d Duration) TimeDuration() time.Duration {
+	return time.Duration(d.Years*365+d.Months*30+d.Days)*24*time.Hour + d.Time
+}
+
+// AddTo returns given time with this duration added (calendar-aware)
+func (d Duration) AddTo(t time.Time) time.Time {
+	return t.AddDate(d.Years, d.Months, d.Days).Add(d.Time)
+}
+
+// String returns the string representation of this duration (eg: '1년 6개월 2시간 30분')
+func (d Duration) String() string {
+	var parts []string
+	for _, part := range []struct {
+		value int
+		unit  string
+	}{
+		{d.Years, ExpressionYear1},
+		{d.Months, ExpressionMonth3},
+		{d.Days, ExpressionDay1},
+		{int(d.Time / time.Hour), ExpressionTimeHour1},
+		{int(d.Time % time.Hour / time.Minute), ExpressionTimeMinute1},
+		{int(d.Time % time.Minute / time.Second), ExpressionTimeSecond1},
+	} {
+		if part.value != 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", part.value, part.unit))
+		}
+	}
+	if len(parts) <= 0 {
+		return "0" + ExpressionTimeSecond1
+	}
+	return strings.Join(parts, " ")
+}
+
+// ExtractDurationMatches extracts all durations and their positions from given string
+//
+// durations are number + unit sequences (eg: '2시간 30분', '1년 6개월') followed by
+// '동안', '간', '내내', '이내', '전', '후', or '뒤', or '반' (eg: '1년 반'), or starting with units which are not
+// ambiguous with dates/times (eg: '3시간', '한 달'), and named ones like '반나절'.
+//
+// (the ones followed by '전', '후', or '뒤' are offsets of relative dates/times,
+// so they are also extracted as dates/times, eg: '3일 후')
+//
+// returned matches are ordered by their positions
+//
+// returns `nil` matches on error
+func (p *Parser) ExtractDurationMatches(str string) (matches []DurationMatch, err error) {
+	e := p.newExtraction(str, false)
+	matches = e.durations()
+
+	if len(matches) <= 0 {
+		return nil, e.noMatch(SubjectDuration)
+	}
+
+	return matches, nil
+}
+
+// ExtractDurations extracts all durations from given string
+//
+// (when the same text is matched multiple times, the first one is used)
+//
+// returns `nil` durations on error
+//
+// 주어진 한글 string으로부터 기간(길이) 추출
+func (p *Parser) ExtractDurations(str string) (durations map[string]Duration, err error) {
+	var matches []DurationMatch
+	if matches, err = p.ExtractDurationMatches(str); err != nil {
+		return nil, err
+	}
+
+	durations = map[string]Duration{}
+	for _, m := range matches {
+		if _, exists := durations[m.Text]; !exists {
+			durations[m.Text] = m.Duration
+		}
+	}
+
+	return durations, nil
+}
+
+// ExtractDuration extracts duration from given string
+//
+// 주어진 한글 string으로부터 패턴에 가장 먼저 맞는 기간(길이) 추출
+func (p *Parser) ExtractDuration(str string) (duration Duration, err error) {
+	var matches []DurationMatch
+	if matches, err = p.ExtractDurationMatches(str); err != nil {
+		return Duration{}, err
+	}
+
+	// the left-most(with the least index) matched duration
+	return matches[0].Duration, nil
+}
+
+// extract all durations
+func (e *extraction) durations() (matches []DurationMatch) {
+	for _, indices := range durationRe.FindAllStringSubmatchIndex(e.normalized, -1) {
+		slices := submatches(e.normalized, indices)
+
+		var duration Duration
+		if slices[4] != "" { // named durations
+			duration = namedDurations[slices[4]]
+		} else {
+			if slices[2] == "" && slices[3] == "" && !hasUnambiguousDurationUnit(slices[1]) { // (eg: not '3일에', but '1년 반')
+				continue
+			}
+			duration = parseDurationUnits(slices[1], slices[2] != "")
+		}
+
+		span := e.span(indices[0], indices[1])
+		e.debugPrint("extracted duration = %s from '%s'", duration, span.Text)
+
+		matches = append(matches, DurationMatch{Span: span, Duration: duration})
+	}
+
+	return matches
+}
+
+// check if given number + unit sequence has any unit which is not ambiguous with dates/times
+func hasUnambiguousDurationUnit(str string) bool {
+	for _, slices := range durationUnitRe.FindAllStringSubmatch(str, -1) {
+		for _, unit := range unambiguousDurationUnits {
+			if slices[2] == unit {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parse number + unit sequence (eg: '1년 6개월', '2시간 30분')
+//
+// ('N월' is also parsed as N months, for relative dates like '3월 후')
+//
+// when `half` is true, a half of the last unit is added (eg: '1시간 반', '1년 반')
+func parseDurationUnits(str string, half bool) (duration Duration) {
+	var last string
+	for _, slices := range durationUnitRe.FindAllStringSubmatch(str, -1) {
+		n, _ := strconv.Atoi(slices[1])
+		last = slices[2]
+
+		switch last {
+		case ExpressionYear1, ExpressionYear2, ExpressionYear3:
+			duration.Years += n
+		case ExpressionMonth1, ExpressionMonth3, ExpressionMonth4:
+			duration.Months += n
+		case ExpressionWeek2, ExpressionWeek1:
+			duration.Days += n * 7
+		case ExpressionDay1, ExpressionDay2, dayCountUnit:
+			duration.Days += n
+		case ExpressionTimeHour1:
+			duration.Time += time.Duration(n) * time.Hour
+		case ExpressionTimeMinute1:
+			duration.Time += time.Duration(n) * time.Minute
+		case ExpressionTimeSecond1:
+			duration.Time += time.Duration(n) * time.Second
+		}
+	}
+
+	if half {
+		switch last {
+		case ExpressionYear1, ExpressionYear2, ExpressionYear3:
+			duration.Months += 6
+		case ExpressionMonth3, ExpressionMonth4:
+			duration.Days += 15
+		case ExpressionWeek2, ExpressionWeek1:
+			duration.Days += 3
+			duration.Time += 12 * time.Hour
+		case ExpressionDay1, ExpressionDay2, dayCountUnit:
+			duration.Time += 12 * time.Hour
+		case ExpressionTimeHour1:
+			duration.Time += 30 * time.Minute
+		case ExpressionTimeMinute1:
+			duration.Time += 30 * time.Second
+		}
+	}
+
+	return duration
+}
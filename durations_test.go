@@ -0,0 +1,67 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExtractDurations(t *testing.T) {
+	for str, expected := range map[string]Duration{
+		`2시간 30분 동안 회의`: {Time: 2*time.Hour + 30*time.Minute},
+		`1년 6개월간 근무`:    {Years: 1, Months: 6},
+		`사흘 동안 비가 왔다`:   {Days: 3},
+		`반나절이면 끝나요`:     {Time: 3 * time.Hour},
+		`한나절`:           {Time: 6 * time.Hour},
+		`한 시간 반 걸려요`:    {Time: 90 * time.Minute},
+		`2주 동안 휴가`:      {Days: 14},
+		`한 달 반`:         {Months: 1, Days: 15},
+		`3시간 10분 뒤에 알림`: {Time: 3*time.Hour + 10*time.Minute},
+		`30분간 휴식`:       {Time: 30 * time.Minute},
+		`3일 이내에 답변`:     {Days: 3},
+		`1년 반 걸렸다`:      {Years: 1, Months: 6},
+		`3일 후에 보자`:      {Days: 3},
+	} {
+		if d, err := ExtractDuration(str); err == nil {
+			if d != expected {
+				t.Errorf("ExtractDuration extracted: %s from string: '%s' (expected: %s)", d, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDuration failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// not durations
+	for _, str := range []string{
+		`3일에 만나요`,
+		`3시 5분 30초`,
+		`2021년 3월`,
+	} {
+		if d, err := ExtractDurations(str); err == nil {
+			t.Errorf("ExtractDurations should fail with string: '%s' (extracted: %v)", str, d)
+		}
+	}
+
+	// multiple durations (with calendar-aware components)
+	str := `작업은 1시간 30분 동안, 휴식은 10분간, 계약은 1년 6개월간`
+	if durations, err := ExtractDurations(str); err == nil {
+		if durations[`1시간 30분 동안`].Time != 90*time.Minute || durations[`10분간`].Time != 10*time.Minute || durations[`1년 6개월간`] != (Duration{Years: 1, Months: 6}) {
+			t.Errorf("ExtractDurations extracted: %v from string: '%s'", durations, str)
+		}
+	} else {
+		t.Errorf("ExtractDurations failed with string: '%s' (error: %s)", str, err)
+	}
+}
+
+func TestDurationAddTo(t *testing.T) {
+	from := time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC)
+	d := Duration{Years: 1, Months: 1, Time: 90 * time.Minute}
+	if to := d.AddTo(from); !to.Equal(time.Date(2022, 3, 3, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("AddTo returned: %s", to)
+	}
+	if d.TimeDuration() != (365+30)*24*time.Hour+90*time.Minute {
+		t.Errorf("TimeDuration returned: %s", d.TimeDuration())
+	}
+	if d.String() != `1년 1개월 1시간 30분` {
+		t.Errorf("String returned: %s", d.String())
+	}
+}
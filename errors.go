@@ -0,0 +1,145 @@
+package lkdp
+
+// 에러
+//
+// errors.Is로 종류(ErrNoMatch, ErrInvalidDate, ...)를, errors.As로 위치(*Error)를 확인 가능
+
+import (
+	"errors"
+	"fmt"
+)
+
+// kinds of errors (usable with errors.Is)
+var (
+	ErrNoMatch     = errors.New("no match")     // no matching expression in the given string
+	ErrInvalidDate = errors.New("invalid date") // date which does not exist (eg: '2월 30일')
+	ErrOutOfRange  = errors.New("out of range") // value out of range (eg: '25시 70분', unsupported lunar years)
+	ErrAmbiguous   = errors.New("ambiguous")    // time without AM/PM, rejected with RejectAmbiguous policy
+)
+
+// Subject is the kind of expressions being extracted
+type Subject string
+
+// subjects of extraction
+const (
+	SubjectDate       Subject = "date"
+	SubjectTime       Subject = "time"
+	SubjectDateTime   Subject = "datetime"
+	SubjectRange      Subject = "range"
+	SubjectDuration   Subject = "duration"
+	SubjectRecurrence Subject = "recurrence"
+)
+
+// Error is an error of extraction (usable with errors.As)
+//
+// eg: ErrNoMatch for '아무 때나', ErrInvalidDate for '2월 30일' in strict mode
+type Error struct {
+	Kind error // ErrNoMatch, ErrInvalidDate, ErrOutOfRange, or ErrAmbiguous
+
+	Subject Subject // kind of expressions being extracted ("" if not from extraction)
+	Input   string  // the given string
+
+	Span // the offending text in the given string (empty for ErrNoMatch)
+
+	Reason string // details in korean (eg: '2021년 2월 30일은 존재하지 않습니다')
+
+	messages Messages // for localized messages (nil = KoreanMessages)
+}
+
+// returns a new error of given kind without span
+func newError(kind error, format string, v ...interface{}) *Error {
+	return &Error{Kind: kind, Reason: fmt.Sprintf(format, v...)}
+}
+
+// returns given error as *Error, wrapping it with given kind if it is not
+func asError(err error, kind error) *Error {
+	var converted *Error
+	if errors.As(err, &converted) {
+		return converted
+	}
+	return newError(kind, "%s", err)
+}
+
+// Error returns the (localized) message of this error
+func (err *Error) Error() string {
+	messages := err.messages
+	if messages == nil {
+		messages = KoreanMessages
+	}
+	return messages.Message(err)
+}
+
+// Unwrap returns the kind of this error
+func (err *Error) Unwrap() error {
+	return err.Kind
+}
+
+// Messages is an interface for localizing messages of errors
+type Messages interface {
+	Message(err *Error) string
+}
+
+// MessagesFunc is a function which implements Messages interface
+type MessagesFunc func(err *Error) string
+
+// Message returns the message of given error
+func (f MessagesFunc) Message(err *Error) string {
+	return f(err)
+}
+
+// localized messages of errors
+var (
+	// KoreanMessages returns messages in korean (default)
+	KoreanMessages Messages = MessagesFunc(func(err *Error) string {
+		subjects := map[Subject]string{
+			SubjectDate:       "날짜",
+			SubjectTime:       "시간",
+			SubjectDateTime:   "날짜/시간",
+			SubjectRange:      "기간",
+			SubjectDuration:   "기간",
+			SubjectRecurrence: "반복 일정",
+		}
+
+		switch err.Kind {
+		case ErrNoMatch:
+			return fmt.Sprintf("해당하는 %s 표현이 없습니다: '%s'", subjects[err.Subject], err.Input)
+		case ErrAmbiguous:
+			return fmt.Sprintf("오전/오후가 모호한 시간입니다: '%s'", err.Text)
+		}
+		if err.Text == "" {
+			return err.Reason
+		}
+		return fmt.Sprintf("유효하지 않은 날짜/시간입니다: '%s' (%s)", err.Text, err.Reason)
+	})
+
+	// EnglishMessages returns messages in english
+	EnglishMessages Messages = MessagesFunc(func(err *Error) string {
+		switch err.Kind {
+		case ErrNoMatch:
+			return fmt.Sprintf("no matching %s expression: '%s'", err.Subject, err.Input)
+		case ErrAmbiguous:
+			return fmt.Sprintf("ambiguous time without AM/PM: '%s'", err.Text)
+		case ErrInvalidDate:
+			if err.Text == "" {
+				return "invalid date"
+			}
+			return fmt.Sprintf("invalid date: '%s'", err.Text)
+		case ErrOutOfRange:
+			if err.Text == "" {
+				return "value out of range"
+			}
+			return fmt.Sprintf("value out of range: '%s'", err.Text)
+		}
+		return fmt.Sprintf("%v: '%s'", err.Kind, err.Text)
+	})
+)
+
+// returns a new error of this extraction
+func (e *extraction) error(kind error, subject Subject, span Span, reason string) *Error {
+	return &Error{Kind: kind, Subject: subject, Input: e.str, Span: span, Reason: reason, messages: e.messages}
+}
+
+// returns an error for no matching expression of given subject
+func (e *extraction) noMatch(subject Subject) error {
+	return e.error(ErrNoMatch, subject, Span{}, "")
+}
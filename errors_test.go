@@ -0,0 +1,80 @@
+package lkdp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestErrors(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 9, 0, 0, 0, loc)
+
+	// no match
+	for subject, extract := range map[Subject]func(str string) error{
+		SubjectDate:       func(str string) error { _, err := ExtractDate(str, true); return err },
+		SubjectTime:       func(str string) error { _, err := ExtractTime(str, true); return err },
+		SubjectDateTime:   func(str string) error { _, err := ExtractDateTime(str, true); return err },
+		SubjectRange:      func(str string) error { _, err := ExtractRange(str, true); return err },
+		SubjectDuration:   func(str string) error { _, err := ExtractDuration(str); return err },
+		SubjectRecurrence: func(str string) error { _, err := ExtractRecurrence(str); return err },
+	} {
+		err := extract(`아무 때나`)
+		if !errors.Is(err, ErrNoMatch) {
+			t.Errorf("extraction of %s should fail with ErrNoMatch (error: %v)", subject, err)
+			continue
+		}
+
+		var e *Error
+		if !errors.As(err, &e) || e.Subject != subject || e.Input != `아무 때나` || e.Text != "" {
+			t.Errorf("extraction of %s failed with unexpected error: %+v", subject, e)
+		}
+	}
+
+	// invalid date
+	if _, err := ExtractDate(`2월 30일`, true, WithReferenceTime(ref), WithStrict(true)); !errors.Is(err, ErrInvalidDate) || errors.Is(err, ErrOutOfRange) {
+		t.Errorf("ExtractDate should fail with ErrInvalidDate (error: %v)", err)
+	}
+	if _, err := ExtractDate(`99999년 3월 5일`, true, WithReferenceTime(ref), WithStrict(true)); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("ExtractDate should fail with ErrOutOfRange (error: %v)", err)
+	}
+	if _, err := ExtractDate(`99999년 3월 5일`, true, WithReferenceTime(ref)); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("ExtractDate should fail with ErrNoMatch (error: %v)", err)
+	}
+
+	// out of range
+	var e *Error
+	if _, err := ExtractTime(`오늘 25시 70분`, true, WithReferenceTime(ref), WithStrict(true)); !errors.Is(err, ErrOutOfRange) || !errors.As(err, &e) {
+		t.Errorf("ExtractTime should fail with ErrOutOfRange (error: %v)", err)
+	} else if e.Text != `25시 70분` || e.Subject != SubjectTime || `오늘 25시 70분`[e.Start:e.End] != e.Text {
+		t.Errorf("ExtractTime failed with unexpected error: %+v", e)
+	}
+	if _, err := LunarToSolar(1800, 1, 1, false, loc); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("LunarToSolar should fail with ErrOutOfRange (error: %v)", err)
+	}
+
+	// ambiguous
+	if _, err := ExtractDateTime(`내일 3시`, true, WithReferenceTime(ref), WithAmbiguityPolicy(RejectAmbiguous)); !errors.Is(err, ErrAmbiguous) || !errors.As(err, &e) || e.Text != `3시` {
+		t.Errorf("ExtractDateTime should fail with ErrAmbiguous (error: %v)", err)
+	}
+	if _, err := ExtractDateTime(`내일 오후 3시`, true, WithReferenceTime(ref), WithAmbiguityPolicy(RejectAmbiguous)); err != nil {
+		t.Errorf("ExtractDateTime failed (error: %v)", err)
+	}
+
+	// localized messages
+	for _, test := range []struct {
+		opts     []Option
+		expected string
+	}{
+		{nil, `해당하는 날짜 표현이 없습니다: '아무 때나'`},
+		{[]Option{WithMessages(EnglishMessages)}, `no matching date expression: '아무 때나'`},
+		{[]Option{WithMessages(MessagesFunc(func(err *Error) string { return "없음" }))}, `없음`},
+	} {
+		if _, err := ExtractDate(`아무 때나`, true, test.opts...); err == nil || err.Error() != test.expected {
+			t.Errorf("ExtractDate failed with message: %v (expected: %s)", err, test.expected)
+		}
+	}
+	if _, err := ExtractDate(`2월 30일`, true, WithReferenceTime(ref), WithStrict(true), WithMessages(EnglishMessages)); err == nil || err.Error() != `invalid date: '2월 30일'` {
+		t.Errorf("ExtractDate failed with message: %v", err)
+	}
+}
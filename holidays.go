@@ -0,0 +1,135 @@
+package lkdp
+
+// 공휴일 및 기념일
+//
+// eg: '추석', '올해 설날', '지난 크리스마스', '내년 추석 연휴'
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Holiday is a (public) holiday or a named day
+type Holiday struct {
+	Name    string   // representative name (eg: '추석')
+	Aliases []string // other names (eg: '한가위')
+
+	Month, Day int  // date of the holiday
+	Lunar      bool // whether the date is of the lunar calendar or not
+
+	DaysBefore, DaysAfter int // extra days of the holidays (연휴) before/after the date (eg: 1, 1 for '추석')
+
+	Public bool // whether it is a korean public holiday (공휴일) or not
+}
+
+// catalog of holidays
+var holidays = []Holiday{
+	{Name: `신정`, Aliases: []string{`양력설`}, Month: 1, Day: 1, Public: true},
+	{Name: `설날`, Aliases: []string{`구정`, `음력설`, `설`}, Month: 1, Day: 1, Lunar: true, DaysBefore: 1, DaysAfter: 1, Public: true},
+	{Name: `정월대보름`, Aliases: []string{`대보름`}, Month: 1, Day: 15, Lunar: true},
+	{Name: `발렌타인데이`, Aliases: []string{`밸런타인데이`}, Month: 2, Day: 14},
+	{Name: `삼일절`, Aliases: []string{`3.1절`, `3·1절`}, Month: 3, Day: 1, Public: true},
+	{Name: `화이트데이`, Month: 3, Day: 14},
+	{Name: `만우절`, Month: 4, Day: 1},
+	{Name: `식목일`, Month: 4, Day: 5},
+	{Name: `근로자의 날`, Aliases: []string{`노동절`}, Month: 5, Day: 1},
+	{Name: `어린이날`, Month: 5, Day: 5, Public: true},
+	{Name: `어버이날`, Month: 5, Day: 8},
+	{Name: `스승의 날`, Month: 5, Day: 15},
+	{Name: `석가탄신일`, Aliases: []string{`부처님 오신 날`, `초파일`}, Month: 4, Day: 8, Lunar: true, Public: true},
+	{Name: `단오`, Month: 5, Day: 5, Lunar: true},
+	{Name: `현충일`, Month: 6, Day: 6, Public: true},
+	{Name: `제헌절`, Month: 7, Day: 17},
+	{Name: `칠석`, Month: 7, Day: 7, Lunar: true},
+	{Name: `광복절`, Month: 8, Day: 15, Public: true},
+	{Name: `추석`, Aliases: []string{`한가위`, `중추절`}, Month: 8, Day: 15, Lunar: true, DaysBefore: 1, DaysAfter: 1, Public: true},
+	{Name: `국군의 날`, Month: 10, Day: 1},
+	{Name: `개천절`, Month: 10, Day: 3, Public: true},
+	{Name: `한글날`, Month: 10, Day: 9, Public: true},
+	{Name: `할로윈`, Aliases: []string{`핼러윈`}, Month: 10, Day: 31},
+	{Name: `빼빼로데이`, Month: 11, Day: 11},
+	{Name: `크리스마스 이브`, Aliases: []string{`성탄 전야`}, Month: 12, Day: 24},
+	{Name: `크리스마스`, Aliases: []string{`성탄절`, `기독탄신일`}, Month: 12, Day: 25, Public: true},
+}
+
+// names which are recognized only with '연휴' (eg: '설 연휴')
+var holidayNamesOnlyWithHolidays = []string{
+	`설`,
+}
+
+// holidays by names (spaces removed)
+var holidaysByName = map[string]Holiday{}
+
+// Holidays returns the catalog of holidays
+func Holidays() []Holiday {
+	copied := make([]Holiday, len(holidays))
+	copy(copied, holidays)
+	return copied
+}
+
+// LookupHoliday returns the holiday with given name (or alias)
+//
+// eg: LookupHoliday("한가위") => 추석
+func LookupHoliday(name string) (holiday Holiday, exists bool) {
+	holiday, exists = holidaysByName[removeSpaces(name)]
+	return holiday, exists
+}
+
+// Date returns the (solar) date of this holiday in given year
+//
+// for lunar holidays, given year is used as the lunar year
+func (h Holiday) Date(year int, location *time.Location) (time.Time, error) {
+	if h.Lunar {
+		return LunarToSolar(year, h.Month, h.Day, false, location)
+	}
+
+	date := time.Date(year, time.Month(h.Month), h.Day, 0, 0, 0, 0, location)
+	if date.Month() != time.Month(h.Month) {
+		return time.Time{}, newError(ErrInvalidDate, "존재하지 않는 날짜입니다: %d년 %d월 %d일", year, h.Month, h.Day)
+	}
+	return date, nil
+}
+
+// Period returns the first and the last date of this holiday (연휴) in given year
+//
+// (for holidays without extra days, from == to)
+func (h Holiday) Period(year int, location *time.Location) (from, to time.Time, err error) {
+	var date time.Time
+	if date, err = h.Date(year, location); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return date.AddDate(0, 0, -h.DaysBefore), date.AddDate(0, 0, h.DaysAfter), nil
+}
+
+// returns given string without spaces
+func removeSpaces(str string) string {
+	return strings.Join(strings.Fields(str), "")
+}
+
+// returns the regular expression of holiday names, longer ones first
+func holidayNamesExpression() string {
+	var names []string
+	for _, h := range holidays {
+		names = append(names, h.Name)
+		names = append(names, h.Aliases...)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+
+	for i, name := range names {
+		names[i] = strings.ReplaceAll(regexp.QuoteMeta(name), " ", `\s*`)
+	}
+	return strings.Join(names, "|")
+}
+
+func init() {
+	for _, h := range holidays {
+		holidaysByName[removeSpaces(h.Name)] = h
+		for _, alias := range h.Aliases {
+			holidaysByName[removeSpaces(alias)] = h
+		}
+	}
+}
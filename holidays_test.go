@@ -0,0 +1,98 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHolidayDates(t *testing.T) {
+	for name, expected := range map[string]string{
+		`추석`:     `2021-09-21`,
+		`한가위`:    `2021-09-21`,
+		`설날`:     `2021-02-12`,
+		`석가탄신일`:  `2021-05-19`,
+		`부처님오신날`: `2021-05-19`,
+		`크리스마스`:  `2021-12-25`,
+		`근로자의날`:  `2021-05-01`,
+	} {
+		if holiday, exists := LookupHoliday(name); exists {
+			if date, err := holiday.Date(2021, time.UTC); err != nil || date.Format("2006-01-02") != expected {
+				t.Errorf("Date returned: %s for holiday: %s (expected: %s, error: %v)", date.Format("2006-01-02"), name, expected, err)
+			}
+		} else {
+			t.Errorf("LookupHoliday failed with name: %s", name)
+		}
+	}
+
+	if _, exists := LookupHoliday(`없는날`); exists {
+		t.Errorf("LookupHoliday should fail with unknown name")
+	}
+
+	// period of holidays
+	holiday, _ := LookupHoliday(`추석`)
+	if from, to, err := holiday.Period(2021, time.UTC); err != nil || from.Format("2006-01-02") != `2021-09-20` || to.Format("2006-01-02") != `2021-09-22` {
+		t.Errorf("Period returned: %s ~ %s for holiday: %s (error: %v)", from.Format("2006-01-02"), to.Format("2006-01-02"), holiday.Name, err)
+	}
+}
+
+func TestExtractHolidays(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 9, 0, 0, 0, loc)
+
+	for str, expected := range map[string]string{
+		`추석에 고향 가요`:       `2021-09-21`,
+		`삼일절 기념식`:         `2021-03-01`, // today
+		`3.1절 기념식`:        `2021-03-01`,
+		`설날에 만나요`:         `2022-02-01`, // already passed
+		`지난 설날에 만났지`:      `2021-02-12`,
+		`작년 크리스마스`:        `2020-12-25`,
+		`내년 어린이날`:         `2022-05-05`,
+		`올해 한글날`:          `2021-10-09`,
+		`2020년 추석`:        `2020-10-01`,
+		`크리스마스 이브에 보자`:    `2021-12-24`,
+		`스승의 날 선물`:        `2021-05-15`,
+		`다가오는 광복절`:        `2021-08-15`,
+		`저번 크리스마스는 즐거웠다`:  `2020-12-25`,
+		`현충일 오전 10시에 묵념`:  `2021-06-06`,
+		`석가탄신일은 음력 4월 8일`: `2021-05-19`,
+		`재작년 추석`:          `2019-09-13`,
+	} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+			if m := matches[0]; m.Date.Format("2006-01-02") != expected || m.Rule != RuleDateHoliday1 || !m.Until.IsZero() {
+				t.Errorf("ExtractDateMatches extracted: %s (%s, rule: %s) from string: %s (expected: %s)", m.Date.Format("2006-01-02"), m.Text, m.Rule, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed with string: %s (error: %s)", str, err)
+		}
+	}
+
+	// holidays (연휴) as periods
+	for str, expected := range map[string][]string{
+		`추석 연휴에 여행 가자`: {`2021-09-20`, `2021-09-22`},
+		`설 연휴`:         {`2022-01-31`, `2022-02-02`},
+		`2020년 설날 연휴`:  {`2020-01-24`, `2020-01-26`},
+		`지난 설 연휴`:      {`2021-02-11`, `2021-02-13`},
+		`어린이날 연휴`:      {`2021-05-05`, `2021-05-05`},
+	} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+			if m := matches[0]; m.Date.Format("2006-01-02") != expected[0] || m.Until.Format("2006-01-02") != expected[1] {
+				t.Errorf("ExtractDateMatches extracted: %s ~ %s (%s) from string: %s (expected: %v)", m.Date.Format("2006-01-02"), m.Until.Format("2006-01-02"), m.Text, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed with string: %s (error: %s)", str, err)
+		}
+	}
+
+	// should not match
+	for _, str := range []string{
+		`설명서를 읽어보세요`,
+		`설은 언제야`,
+		`구정물이 튀었다`,
+		`신정동에 산다`,
+		`혁신정책 발표`,
+	} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+			t.Errorf("ExtractDateMatches should fail with string: %s (extracted: %v)", str, matches)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package lkdp
+
+// iCalendar(RFC 5545) 내보내기
+//
+// 추출된 날짜/시간, 기간, 반복 일정을 VEVENT로 변환
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PRODID of exported calendars
+const icsProductID = "-//meinside//lazy-korean-date-parser-go//KO"
+
+// max length (in octets) of a content line, excluding CRLF
+const icsMaxLineLength = 75
+
+// Event is a calendar event which can be exported as a VEVENT of iCalendar
+type Event struct {
+	UID     string // unique id (generated from other fields if empty)
+	Summary string
+
+	Start time.Time // DTSTART
+	End   time.Time // DTEND (exclusive, zero = no DTEND)
+
+	AllDay bool // whether Start and End are dates without times (VALUE=DATE)
+
+	Recurrence *Recurrence // RRULE (nil = no recurrence)
+
+	Stamp time.Time // DTSTAMP (reference time of the extraction for events of matches, zero = Start)
+}
+
+// Event returns a calendar event of this date/time with given summary
+//
+// (dates without times become all-day events, through the last date of periods like '추석 연휴' or '3월')
+func (m DateTimeMatch) Event(summary string) Event {
+	if m.TimeMatch == nil {
+		last := m.DateTime
+		if m.DateMatch != nil && !m.DateMatch.Until.IsZero() {
+			last = m.DateMatch.Until
+		}
+		return Event{Summary: summary, Start: m.DateTime, End: last.AddDate(0, 0, 1), AllDay: true, Stamp: m.now}
+	}
+	return Event{Summary: summary, Start: m.DateTime, Stamp: m.now}
+}
+
+// Event returns a calendar event of this range with given summary
+//
+// (ranges of dates without times become all-day events, including the last date)
+func (m RangeMatch) Event(summary string) Event {
+	if m.From.TimeMatch == nil && m.To.TimeMatch == nil {
+		return Event{Summary: summary, Start: m.From.DateTime, End: m.To.DateTime.AddDate(0, 0, 1), AllDay: true, Stamp: m.now}
+	}
+	return Event{Summary: summary, Start: m.From.DateTime, End: m.To.DateTime, Stamp: m.now}
+}
+
+// Event returns a calendar event of this recurrence with given summary
+//
+// (recurrences without times become all-day events)
+//
+// DTSTART of the event is the first occurrence, to be synchronized with the RRULE
+func (m RecurrenceMatch) Event(summary string) Event {
+	r := m.Recurrence
+
+	start := r.Start
+	if first, exists := r.Next(r.Start.Add(-time.Second)); exists {
+		start = first
+	}
+
+	if len(r.ByHour) <= 0 {
+		return Event{Summary: summary, Start: start, End: start.AddDate(0, 0, 1), AllDay: true, Recurrence: &r, Stamp: m.now}
+	}
+	return Event{Summary: summary, Start: start, Recurrence: &r, Stamp: m.now}
+}
+
+// VEvent returns the VEVENT component of this event
+//
+// (VTIMEZONE components for its TZID are not included, use VCalendar for a complete calendar)
+func (ev Event) VEvent() string {
+	var b strings.Builder
+	ev.writeVEvent(&b)
+	return b.String()
+}
+
+// VCalendar returns an iCalendar object (.ics) with given events
+// and VTIMEZONE components for their locations
+func VCalendar(events ...Event) string {
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:"+icsProductID)
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+
+	// VTIMEZONE of each location
+	years := map[string][2]int{}
+	locations := map[string]*time.Location{}
+	for _, ev := range events {
+		if ev.AllDay || !hasTZID(ev.Start.Location()) {
+			continue
+		}
+		for _, t := range []time.Time{ev.Start, ev.End} {
+			if t.IsZero() {
+				continue
+			}
+			name := t.Location().String()
+			span, exists := years[name]
+			if !exists || t.Year() < span[0] {
+				span[0] = t.Year()
+			}
+			if !exists || t.Year() > span[1] {
+				span[1] = t.Year()
+			}
+			years[name], locations[name] = span, t.Location()
+		}
+		if ev.Recurrence != nil { // a year more for recurrences
+			name := ev.Start.Location().String()
+			span := years[name]
+			span[1]++
+			years[name] = span
+		}
+	}
+	names := make([]string, 0, len(locations))
+	for name := range locations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeVTimezone(&b, locations[name], years[name][0], years[name][1])
+	}
+
+	for _, ev := range events {
+		ev.writeVEvent(&b)
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// write VEVENT component of this event
+//
+// (DTSTAMP is required by RFC 5545, so Start is written when Stamp is zero)
+func (ev Event) writeVEvent(b *strings.Builder) {
+	stamp := ev.Stamp
+	if stamp.IsZero() {
+		stamp = ev.Start
+	}
+
+	writeICSLine(b, "BEGIN:VEVENT")
+	writeICSLine(b, "UID:"+escapeICSText(ev.uid()))
+	writeICSLine(b, "DTSTAMP:"+stamp.UTC().Format("20060102T150405Z"))
+	writeICSLine(b, formatICSTime("DTSTART", ev.Start, ev.AllDay))
+	if !ev.End.IsZero() {
+		writeICSLine(b, formatICSTime("DTEND", ev.End, ev.AllDay))
+	}
+	if ev.Recurrence != nil {
+		writeICSLine(b, "RRULE:"+ev.Recurrence.RRule())
+	}
+	if ev.Summary != "" {
+		writeICSLine(b, "SUMMARY:"+escapeICSText(ev.Summary))
+	}
+	writeICSLine(b, "END:VEVENT")
+}
+
+// returns the UID of this event (generated from other fields if empty)
+func (ev Event) uid() string {
+	if ev.UID != "" {
+		return ev.UID
+	}
+
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s|%s|%s|%v", ev.Summary, ev.Start.Format(time.RFC3339), ev.End.Format(time.RFC3339), ev.AllDay)
+	if ev.Recurrence != nil {
+		fmt.Fprintf(h, "|%s", ev.Recurrence.RRule())
+	}
+	return fmt.Sprintf("%016x@lkdp", h.Sum64())
+}
+
+// format a date/time property (eg: 'DTSTART;TZID=Asia/Seoul:20210302T140000', 'DTSTART;VALUE=DATE:20210302')
+func formatICSTime(name string, t time.Time, allDay bool) string {
+	if allDay {
+		return name + ";VALUE=DATE:" + t.Format("20060102")
+	}
+	if !hasTZID(t.Location()) {
+		return name + ":" + t.UTC().Format("20060102T150405Z")
+	}
+	return name + ";TZID=" + t.Location().String() + ":" + t.Format("20060102T150405")
+}
+
+// check if given location can be written as a TZID (UTC and Local are written in UTC)
+func hasTZID(location *time.Location) bool {
+	name := location.String()
+	return name != "UTC" && name != "Local" && name != ""
+}
+
+// write VTIMEZONE component of given location, with its offset transitions in given years
+func writeVTimezone(b *strings.Builder, location *time.Location, fromYear, toYear int) {
+	start := time.Date(fromYear, 1, 1, 0, 0, 0, 0, location)
+	end := time.Date(toYear+1, 1, 1, 0, 0, 0, 0, location)
+
+	// find offset transitions (daily, then binary search in seconds)
+	type transition struct {
+		at       time.Time
+		from, to int
+	}
+	var transitions []transition
+	_, offset := start.Zone()
+	for t := start; t.Before(end); t = t.Add(24 * time.Hour) {
+		next := t.Add(24 * time.Hour)
+		if _, nextOffset := next.Zone(); nextOffset != offset {
+			lo, hi := t.Unix(), next.Unix()
+			for hi-lo > 1 {
+				mid := (lo + hi) / 2
+				if _, o := time.Unix(mid, 0).In(location).Zone(); o == offset {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			transitions = append(transitions, transition{at: time.Unix(hi, 0).In(location), from: offset, to: nextOffset})
+			offset = nextOffset
+		}
+	}
+
+	writeICSLine(b, "BEGIN:VTIMEZONE")
+	writeICSLine(b, "TZID:"+location.String())
+
+	// observance from the start
+	_, initial := start.Zone()
+	daylight := len(transitions) > 0 && transitions[0].to < initial
+	writeVTimezoneRule(b, start, initial, initial, daylight)
+
+	for _, t := range transitions {
+		writeVTimezoneRule(b, t.at, t.from, t.to, t.to > t.from)
+	}
+
+	writeICSLine(b, "END:VTIMEZONE")
+}
+
+// write STANDARD/DAYLIGHT sub-component of a VTIMEZONE
+func writeVTimezoneRule(b *strings.Builder, at time.Time, from, to int, daylight bool) {
+	kind := "STANDARD"
+	if daylight {
+		kind = "DAYLIGHT"
+	}
+	name, _ := at.Zone()
+
+	// DTSTART of an observance is written in local time of the previous offset
+	local := at.UTC().Add(time.Duration(from) * time.Second)
+
+	writeICSLine(b, "BEGIN:"+kind)
+	writeICSLine(b, "DTSTART:"+local.Format("20060102T150405"))
+	writeICSLine(b, "TZOFFSETFROM:"+formatICSOffset(from))
+	writeICSLine(b, "TZOFFSETTO:"+formatICSOffset(to))
+	writeICSLine(b, "TZNAME:"+escapeICSText(name))
+	writeICSLine(b, "END:"+kind)
+}
+
+// format an offset in seconds (eg: '+0900', '-0430')
+func formatICSOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+}
+
+// escape TEXT value (backslashes, semicolons, commas, and newlines)
+func escapeICSText(str string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		`;`, `\;`,
+		`,`, `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(str)
+}
+
+// write a content line, folded at 75 octets (without breaking UTF-8 characters) and ended with CRLF
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icsMaxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icsMaxLineLength - 1 // continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// check if given byte is the first byte of a UTF-8 character
+func isUTF8Start(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,82 @@
+package lkdp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVCalendar(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 9, 0, 0, 0, loc)
+	stamp := time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	// range
+	matches, err := ExtractRangeMatches(`다음 주 화요일 오후 2시부터 4시까지 회의`, true, WithReferenceTime(ref))
+	if err != nil {
+		t.Fatalf("ExtractRangeMatches failed (error: %s)", err)
+	}
+	ics := VCalendar(matches[0].Event(`회의; 3층, 대회의실`))
+	for _, expected := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Asia/Seoul\r\n",
+		"TZOFFSETTO:+0900\r\n",
+		"DTSTAMP:20210301T000000Z\r\n", // (reference time)
+		"DTSTART;TZID=Asia/Seoul:20210309T140000\r\n",
+		"DTEND;TZID=Asia/Seoul:20210309T160000\r\n",
+		"SUMMARY:회의\\; 3층\\, 대회의실\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, expected) {
+			t.Errorf("VCalendar returned: %s (expected to contain: %q)", ics, expected)
+		}
+	}
+
+	// all-day
+	dateTimes, _ := ExtractDateTimeMatches(`3월 5일 휴가`, true, WithReferenceTime(ref))
+	if vevent := dateTimes[0].Event(`휴가`).VEvent(); !strings.Contains(vevent, "DTSTAMP:20210301T000000Z\r\nDTSTART;VALUE=DATE:20210305\r\nDTEND;VALUE=DATE:20210306\r\n") {
+		t.Errorf("VEvent returned: %s", vevent)
+	}
+
+	// recurrence
+	recurrences, _ := ExtractRecurrenceMatches(`격주 금요일 오후 3시 주간 보고`, WithReferenceTime(ref))
+	if vevent := strings.ReplaceAll(recurrences[0].Event(`주간 보고`).VEvent(), "\r\n ", ""); !strings.Contains(vevent, "DTSTART;TZID=Asia/Seoul:20210305T150000\r\nRRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=FR;BYHOUR=15;BYMINUTE=0;BYSECOND=0;WKST=MO\r\n") {
+		t.Errorf("VEvent returned: %s", vevent)
+	}
+
+	// UTC, with given stamp
+	if vevent := (Event{Summary: `utc`, Start: time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC), Stamp: stamp}).VEvent(); !strings.Contains(vevent, "DTSTAMP:20210302T000000Z\r\nDTSTART:20210301T090000Z\r\n") {
+		t.Errorf("VEvent returned: %s", vevent)
+	}
+
+	// without stamp (filled with the start, not the current time)
+	if vevent := (Event{Summary: `no stamp`, Start: time.Date(2021, 3, 1, 9, 0, 0, 0, loc)}).VEvent(); !strings.Contains(vevent, "DTSTAMP:20210301T000000Z\r\n") {
+		t.Errorf("VEvent returned: %s", vevent)
+	}
+
+	// daylight saving time
+	newYork, _ := time.LoadLocation("America/New_York")
+	ics = VCalendar(Event{Summary: `dst`, Start: time.Date(2021, 7, 1, 9, 0, 0, 0, newYork), Stamp: stamp})
+	for _, expected := range []string{
+		"BEGIN:DAYLIGHT\r\nDTSTART:20210314T020000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0400\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20211107T020000\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0500\r\n",
+	} {
+		if !strings.Contains(ics, expected) {
+			t.Errorf("VCalendar returned: %s (expected to contain: %q)", ics, expected)
+		}
+	}
+}
+
+func TestICSLineFolding(t *testing.T) {
+	summary := strings.Repeat(`가나다라마바사`, 10)
+	vevent := (Event{Summary: summary, Start: time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC)}).VEvent()
+
+	for _, line := range strings.Split(strings.TrimSuffix(vevent, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line is too long: %d octets", len(line))
+		}
+	}
+	if unfolded := strings.ReplaceAll(vevent, "\r\n ", ""); !strings.Contains(unfolded, "SUMMARY:"+summary+"\r\n") {
+		t.Errorf("folded line is broken: %s", vevent)
+	}
+}
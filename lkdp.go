@@ -4,10 +4,10 @@ package lkdp
 
 import (
 	"fmt"
-	"log"
 	"regexp"
-	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -35,9 +35,11 @@ const (
 
 	ExpressionYear1  = `년`
 	ExpressionYear2  = `年`
+	ExpressionYear3  = `해`
 	ExpressionMonth1 = `월`
 	ExpressionMonth2 = `月`
 	ExpressionMonth3 = `개월`
+	ExpressionMonth4 = `달`
 	ExpressionDay1   = `일`
 	ExpressionDay2   = `日`
 
@@ -68,9 +70,146 @@ const (
 	ExpressionDateSeparator1 = `\-`
 	ExpressionDateSeparator2 = `\.`
 	ExpressionDateSeparator3 = `/`
+
+	ExpressionLunar1     = `음력`
+	ExpressionLunar2     = `陰曆`
+	ExpressionLeapMonth1 = `윤`
+	ExpressionLeapMonth2 = `閏`
+
+	ExpressionWeekThis1       = `이번`
+	ExpressionWeekThis2       = `금`
+	ExpressionWeekNext1       = `다음`
+	ExpressionWeekNext2       = `차`
+	ExpressionWeekNext3       = `담`
+	ExpressionWeekAfterNext1  = `다다음`
+	ExpressionWeekBefore1     = `지난`
+	ExpressionWeekBefore2     = `저번`
+	ExpressionWeekBeforeLast1 = `지지난`
+	ExpressionWeek1           = `주`
+	ExpressionWeek2           = `주일`
+
+	ExpressionYearThis1   = `올해`
+	ExpressionYearThis2   = `금년`
+	ExpressionYearThis3   = `올`
+	ExpressionYearBefore2 = `지난해`
+
+	ExpressionThis1     = `이번`
+	ExpressionNext1     = `다음`
+	ExpressionUpcoming1 = `다가오는`
+	ExpressionUpcoming2 = `다가올`
+	ExpressionPrevious1 = `지난`
+	ExpressionPrevious2 = `저번`
+
+	ExpressionHolidays1 = `연휴`
+
+	ExpressionYearAbbreviation1 = `'` // ''21년'
+	ExpressionYearAbbreviation2 = `‘`
+	ExpressionYearAbbreviation3 = `’`
+	ExpressionBirth1            = `생` // '81년생'
+
+	ExpressionHalf1       = `반기`
+	ExpressionFirstHalf1  = `상`
+	ExpressionFirstHalf2  = `전`
+	ExpressionSecondHalf1 = `하`
+	ExpressionSecondHalf2 = `후`
+	ExpressionQuarter1    = `분기`
+
+	ExpressionMonthThis1   = `이`
+	ExpressionMonthNext1   = `익`
+	ExpressionMonthBefore1 = `전`
+
+	ExpressionEarly1   = `초순`
+	ExpressionEarly2   = `초`
+	ExpressionMiddle1  = `중순`
+	ExpressionLate1    = `하순`
+	ExpressionLate2    = `말`
+	ExpressionLastDay1 = `말일`
+
+	ExpressionYear4           = `연` // '연말', '연초'
+	ExpressionYearBeforeLast2 = `재작년`
+
+	ExpressionOrdinal1 = `째` // '둘째', '2번째'
+	ExpressionOrdinal2 = `번`
+	ExpressionFirst1   = `첫`
+	ExpressionLast1    = `마지막`
+	ExpressionDay3     = `날` // '마지막 날'
+	ExpressionOf1      = `의` // '이달의 마지막 날'
+
+	ExpressionWeekday1 = `요일`
+	ExpressionWeekday2 = `曜日`
+
+	ExpressionEvery1          = `매`  // '매 주말'
+	ExpressionEvery2          = `마다` // '화요일마다'
+	ExpressionEveryDay1       = `매일매일`
+	ExpressionEveryDay2       = `매일같이`
+	ExpressionEveryDay3       = `매일`
+	ExpressionEveryDay4       = `날마다`
+	ExpressionEveryOtherDay1  = `격일`
+	ExpressionWeekdays1       = `평일`
+	ExpressionWeekdays2       = `주중`
+	ExpressionWeekend1        = `주말`
+	ExpressionEveryWeek1      = `매주`
+	ExpressionEveryOtherWeek1 = `격주`
+	ExpressionEveryMonth1     = `매달`
+	ExpressionEveryMonth2     = `매월`
+	ExpressionEveryMonth3     = `다달이`
+	ExpressionEveryYear1      = `매년`
+	ExpressionEveryYear2      = `매해`
+	ExpressionEveryYear3      = `해마다`
+
+	ExpressionAnd1 = `와` // '화요일과 목요일'
+	ExpressionAnd2 = `과`
+	ExpressionAnd3 = `하고`
+
+	ExpressionMonday1    = `월`
+	ExpressionMonday2    = `月`
+	ExpressionTuesday1   = `화`
+	ExpressionTuesday2   = `火`
+	ExpressionWednesday1 = `수`
+	ExpressionWednesday2 = `水`
+	ExpressionThursday1  = `목`
+	ExpressionThursday2  = `木`
+	ExpressionFriday1    = `금`
+	ExpressionFriday2    = `金`
+	ExpressionSaturday1  = `토`
+	ExpressionSaturday2  = `土`
+	ExpressionSunday1    = `일`
+	ExpressionSunday2    = `日`
+)
+
+// rule names
+const (
+	RuleDateRel1       = "dateRelRe1"
+	RuleDateRel2       = "dateRelRe2"
+	RuleDateWeekday1   = "dateWeekdayRe1"
+	RuleDateRelMonth1  = "dateRelMonthRe1"
+	RuleDateRelWeek1   = "dateRelWeekRe1"
+	RuleDateRelYear1   = "dateRelYearRe1"
+	RuleDateOrdinal1   = "dateOrdinalRe1"
+	RuleDateLunar1     = "dateLunarRe1"
+	RuleDateHoliday1   = "dateHolidayRe1"
+	RuleDateBirthYear1 = "dateBirthYearRe1"
+	RuleDateExact1     = "dateExactRe1"
+	RuleDateExact2     = "dateExactRe2"
+	RuleDatePartial1   = "datePartialRe1"
+	RuleTimeRel1       = "timeRelRe1"
+	RuleTimeExact1     = "timeExactRe1"
+	RuleTimeExact2     = "timeExactRe2"
+	RuleTimeDayPeriod1 = "timeDayPeriodRe1"
+
+	RuleRecurrenceDaily1    = "recurrenceDailyRe1"
+	RuleRecurrenceWeekdays1 = "recurrenceWeekdaysRe1"
+	RuleRecurrenceWeekly1   = "recurrenceWeeklyRe1"
+	RuleRecurrenceMonthly1  = "recurrenceMonthlyRe1"
+	RuleRecurrenceYearly1   = "recurrenceYearlyRe1"
 )
 
-// Verbose flag for debugging
+// particles which can follow words at their boundaries (eg: '점심에', '추석까지', '저녁이야')
+const boundaryParticles = `에는|에|부터|까지|쯤|이|은|는|의|도`
+
+// Verbose flag for debugging (of the default parser)
+//
+// (it is read without locks, so set it before extracting concurrently, and do not change it while extracting)
 var Verbose bool
 
 // Hms struct for hh:mm:ss
@@ -83,17 +222,39 @@ type Hms struct {
 	Ambiguous bool // whether this time is ambiguous or not (eg: AM/PM)
 }
 
-var _location *time.Location
-
-var dateExactRe1, dateExactRe2 *regexp.Regexp // 특정 일자
-var dateRelRe1, dateRelRe2 *regexp.Regexp     // 상대 일자
-var timeRelRe1 *regexp.Regexp                 // 상대 시간
-var timeExactRe1, timeExactRe2 *regexp.Regexp // 특정 시간
+var _location *time.Location // default location
+
+var _defaultParser atomic.Value   // default parser (*Parser), replaced as a whole for being used concurrently
+var _defaultParserLock sync.Mutex // lock for replacing the default parser
+
+var dateExactRe1, dateExactRe2 *regexp.Regexp      // 특정 일자
+var dateRelRe1, dateRelRe2 *regexp.Regexp          // 상대 일자
+var dateWeekdayRe1 *regexp.Regexp                  // 요일
+var dateRelMonthRe1, dateRelWeekRe1 *regexp.Regexp // 상대 기간 (월, 주)
+var dateRelYearRe1 *regexp.Regexp                  // 상대 기간 (연)
+var dateOrdinalRe1 *regexp.Regexp                  // 몇째 주 요일, 마지막 날
+var dateLunarRe1 *regexp.Regexp                    // 음력 일자
+var dateHolidayRe1 *regexp.Regexp                  // 공휴일, 기념일
+var dateBirthYearRe1 *regexp.Regexp                // 출생 연도
+var datePartialRe1 *regexp.Regexp                  // 연/월/반기/분기
+var timeRelRe1 *regexp.Regexp                      // 상대 시간
+var timeExactRe1, timeExactRe2 *regexp.Regexp      // 특정 시간
+
+var recurrenceDailyRe1, recurrenceWeekdaysRe1 *regexp.Regexp // 반복 일정 (일, 평일/주말)
+var recurrenceWeeklyRe1, recurrenceMonthlyRe1 *regexp.Regexp // 반복 일정 (주, 월)
+var recurrenceYearlyRe1 *regexp.Regexp                       // 반복 일정 (연)
+var recurrenceWeekdayRe *regexp.Regexp                       // 반복 일정의 요일
+var recurrenceTimeGapRe *regexp.Regexp                       // 반복 일정과 시간 사이
 
 func init() {
 	_location, _ = time.LoadLocation(DefaultLocation)
 
-	dateExactRe1 = regexp.MustCompile(fmt.Sprintf(`((\d{2,})\s*[%s])?\s*((\d{1,2})\s*[%s])?\s*(\d{1,2})\s*[%s]`,
+	dateExactRe1 = regexp.MustCompile(fmt.Sprintf(`((?:[%s])?(\d{2,})\s*[%s])?\s*((\d{1,2})\s*[%s])?\s*(\d{1,2})\s*[%s]`,
+		strings.Join([]string{
+			ExpressionYearAbbreviation1,
+			ExpressionYearAbbreviation2,
+			ExpressionYearAbbreviation3,
+		}, ""),
 		strings.Join([]string{
 			ExpressionYear1,
 			ExpressionYear2,
@@ -107,7 +268,12 @@ func init() {
 			ExpressionDay2,
 		}, ""),
 	))
-	dateExactRe2 = regexp.MustCompile(fmt.Sprintf(`((\d{2,})\s*[%s])?\s*((\d{1,2})\s*[%s]\s*(\d{1,2})\s*[%s]?)`,
+	dateExactRe2 = regexp.MustCompile(fmt.Sprintf(`((?:[%s])?(\d{2,})\s*[%s])?\s*((\d{1,2})\s*[%s]\s*(\d{1,2})\s*[%s]?)`,
+		strings.Join([]string{
+			ExpressionYearAbbreviation1,
+			ExpressionYearAbbreviation2,
+			ExpressionYearAbbreviation3,
+		}, ""),
 		strings.Join([]string{
 			ExpressionDateSeparator1,
 			ExpressionDateSeparator2,
@@ -122,18 +288,27 @@ func init() {
 			ExpressionDateSeparator2,
 		}, ""),
 	))
-	dateRelRe1 = regexp.MustCompile(fmt.Sprintf(`(\d+)\s*(%s)\s*(%s)`, strings.Join([]string{
-		ExpressionYear1,
-		ExpressionYear2,
+	dateRelRe1 = regexp.MustCompile(fmt.Sprintf(`((?:\d+\s*(?:%s)\s*)+|\d+\s*%s\s*)(%s)?\s*(%s)`,
+		strings.Join([]string{
+			ExpressionYear1,
+			ExpressionYear2,
+			ExpressionYear3,
+			ExpressionMonth3,
+			ExpressionMonth4,
+			ExpressionWeek2,
+			ExpressionWeek1,
+			ExpressionDay1,
+			ExpressionDay2,
+			dayCountUnit,
+		}, "|"),
 		ExpressionMonth1,
-		ExpressionMonth3,
-		ExpressionDay1,
-		ExpressionDay2,
-	}, "|"), strings.Join([]string{
-		ExpressionBefore1,
-		ExpressionAfter1,
-		ExpressionAfter2,
-	}, "|")))
+		ExpressionMinuteThirty,
+		strings.Join([]string{
+			ExpressionBefore1,
+			ExpressionAfter1,
+			ExpressionAfter2,
+		}, "|"),
+	))
 	dateRelRe2 = regexp.MustCompile(fmt.Sprintf(`(%s)`, strings.Join([]string{
 		ExpressionYearNext,
 		ExpressionYearAfterNext,
@@ -149,12 +324,321 @@ func init() {
 		ExpressionTheDayAfterTomorrow1,
 		ExpressionTwoDaysAfterTomorrow1,
 	}, "|")))
-	timeRelRe1 = regexp.MustCompile(fmt.Sprintf(`(\d+)\s*(%s)\s*(%s)`,
+	dateWeekdayRe1 = regexp.MustCompile(fmt.Sprintf(`((%s)\s*%s\s*)?([%s])\s*(%s)`,
+		strings.Join([]string{
+			ExpressionWeekAfterNext1,
+			ExpressionWeekBeforeLast1,
+			ExpressionWeekThis1,
+			ExpressionWeekThis2,
+			ExpressionWeekNext1,
+			ExpressionWeekNext2,
+			ExpressionWeekNext3,
+			ExpressionWeekBefore1,
+			ExpressionWeekBefore2,
+		}, "|"),
+		ExpressionWeek1,
+		strings.Join([]string{
+			ExpressionMonday1,
+			ExpressionMonday2,
+			ExpressionTuesday1,
+			ExpressionTuesday2,
+			ExpressionWednesday1,
+			ExpressionWednesday2,
+			ExpressionThursday1,
+			ExpressionThursday2,
+			ExpressionFriday1,
+			ExpressionFriday2,
+			ExpressionSaturday1,
+			ExpressionSaturday2,
+			ExpressionSunday1,
+			ExpressionSunday2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionWeekday1,
+			ExpressionWeekday2,
+		}, "|"),
+	))
+	dateRelMonthRe1 = regexp.MustCompile(fmt.Sprintf(`(?:(?:(?:[%s])?(\d{2,})\s*[%s]\s*)?(\d{1,2})\s*[%s]|(%s)\s*%s|(%s)|(%s))(?:\s*(?:(\d{1,2})\s*[%s]|(%s)))?`,
+		strings.Join([]string{
+			ExpressionYearAbbreviation1,
+			ExpressionYearAbbreviation2,
+			ExpressionYearAbbreviation3,
+		}, ""),
+		strings.Join([]string{
+			ExpressionYear1,
+			ExpressionYear2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionMonth1,
+			ExpressionMonth2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionWeekAfterNext1,
+			ExpressionWeekNext1,
+			ExpressionWeekNext3,
+			ExpressionWeekThis1,
+			ExpressionWeekBeforeLast1,
+			ExpressionWeekBefore1,
+			ExpressionWeekBefore2,
+		}, "|"),
+		ExpressionMonth4,
+		strings.Join([]string{
+			ExpressionMonthThis1 + ExpressionMonth4,
+			ExpressionWeekThis2 + ExpressionMonth1,
+			ExpressionMonthBefore1 + ExpressionMonth1,
+			ExpressionMonthNext1 + ExpressionMonth1,
+		}, "|"),
+		ExpressionMonth1,
+		strings.Join([]string{
+			ExpressionDay1,
+			ExpressionDay2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionLastDay1,
+			ExpressionEarly1,
+			ExpressionEarly2,
+			ExpressionMiddle1,
+			ExpressionLate1,
+			ExpressionLate2,
+		}, "|"),
+	))
+	dateRelWeekRe1 = regexp.MustCompile(fmt.Sprintf(`(?:(%s)\s*%s|(%s)%s)(\s*%s)?|(%s%s)`,
+		strings.Join([]string{
+			ExpressionWeekAfterNext1,
+			ExpressionWeekNext1,
+			ExpressionWeekNext3,
+			ExpressionWeekThis1,
+			ExpressionWeekBeforeLast1,
+			ExpressionWeekBefore1,
+			ExpressionWeekBefore2,
+		}, "|"),
+		ExpressionWeek1,
+		strings.Join([]string{
+			ExpressionWeekThis2,
+			ExpressionWeekNext2,
+		}, "|"),
+		ExpressionWeek1,
+		ExpressionLate2,
+		ExpressionWeek1,
+		ExpressionLate2,
+	))
+	dateRelYearRe1 = regexp.MustCompile(fmt.Sprintf(`(?:(?:[%s])?(\d{2,})\s*[%s]|(%s)|(%s))(?:\s*(?:(\d{1,2})\s*[%s](?:\s*(\d{1,2})\s*[%s])?|(%s)))?`,
+		strings.Join([]string{
+			ExpressionYearAbbreviation1,
+			ExpressionYearAbbreviation2,
+			ExpressionYearAbbreviation3,
+		}, ""),
+		strings.Join([]string{
+			ExpressionYear1,
+			ExpressionYear2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionYearThis1,
+			ExpressionYearThis2,
+			ExpressionYearAfterNext,
+			ExpressionYearNext,
+			ExpressionYearBeforeLast2,
+			ExpressionYearBeforeLast,
+			ExpressionYearBefore,
+			ExpressionYearBefore2,
+			ExpressionYearThis3,
+		}, "|"),
+		ExpressionYear4,
+		strings.Join([]string{
+			ExpressionMonth1,
+			ExpressionMonth2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionDay1,
+			ExpressionDay2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionEarly2,
+			ExpressionLate2,
+		}, "|"),
+	))
+	dateOrdinalRe1 = regexp.MustCompile(fmt.Sprintf(`(?:(?:(?:(?:[%s])?(\d{2,})\s*[%s]\s*)?(\d{1,2})\s*[%s]|(%s)\s*%s|(%s))\s*(?:%s\s*)?)?(?:(?:(%s)\s*(?:%s\s*)?%s|(%s)|(%s))\s*(?:%s\s*)?([%s])\s*(%s)|(%s)\s*%s)`,
+		strings.Join([]string{
+			ExpressionYearAbbreviation1,
+			ExpressionYearAbbreviation2,
+			ExpressionYearAbbreviation3,
+		}, ""),
+		strings.Join([]string{
+			ExpressionYear1,
+			ExpressionYear2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionMonth1,
+			ExpressionMonth2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionWeekAfterNext1,
+			ExpressionWeekNext1,
+			ExpressionWeekNext3,
+			ExpressionWeekThis1,
+			ExpressionWeekBeforeLast1,
+			ExpressionWeekBefore1,
+			ExpressionWeekBefore2,
+		}, "|"),
+		ExpressionMonth4,
+		strings.Join([]string{
+			ExpressionMonthThis1 + ExpressionMonth4,
+			ExpressionWeekThis2 + ExpressionMonth1,
+			ExpressionMonthBefore1 + ExpressionMonth1,
+			ExpressionMonthNext1 + ExpressionMonth1,
+		}, "|"),
+		ExpressionOf1,
+		ordinalsExpression(),
+		ExpressionOrdinal2,
+		ExpressionOrdinal1,
+		ExpressionFirst1,
+		ExpressionLast1,
+		ExpressionWeek1,
+		strings.Join([]string{
+			ExpressionMonday1,
+			ExpressionMonday2,
+			ExpressionTuesday1,
+			ExpressionTuesday2,
+			ExpressionWednesday1,
+			ExpressionWednesday2,
+			ExpressionThursday1,
+			ExpressionThursday2,
+			ExpressionFriday1,
+			ExpressionFriday2,
+			ExpressionSaturday1,
+			ExpressionSaturday2,
+			ExpressionSunday1,
+			ExpressionSunday2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionWeekday1,
+			ExpressionWeekday2,
+		}, "|"),
+		ExpressionLast1,
+		ExpressionDay3,
+	))
+	dateLunarRe1 = regexp.MustCompile(fmt.Sprintf(`(%s)\s*((?:[%s])?(\d{2,})\s*[%s])?\s*(%s)?\s*(\d{1,2})\s*[%s]\s*(\d{1,2})\s*[%s]`,
+		strings.Join([]string{
+			ExpressionLunar1,
+			ExpressionLunar2,
+		}, "|"),
+		strings.Join([]string{
+			ExpressionYearAbbreviation1,
+			ExpressionYearAbbreviation2,
+			ExpressionYearAbbreviation3,
+		}, ""),
+		strings.Join([]string{
+			ExpressionYear1,
+			ExpressionYear2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionLeapMonth1,
+			ExpressionLeapMonth2,
+		}, "|"),
+		strings.Join([]string{
+			ExpressionMonth1,
+			ExpressionMonth2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionDay1,
+			ExpressionDay2,
+		}, ""),
+	))
+	dateHolidayRe1 = regexp.MustCompile(fmt.Sprintf(`(([가-힣])??(?:((?:[%s])?(\d{2,})\s*[%s]|%s)\s*)?(%s)(\s*(%s))?)(?:%s|([가-힣]))?`,
+		strings.Join([]string{
+			ExpressionYearAbbreviation1,
+			ExpressionYearAbbreviation2,
+			ExpressionYearAbbreviation3,
+		}, ""),
+		strings.Join([]string{
+			ExpressionYear1,
+			ExpressionYear2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionYearThis1,
+			ExpressionYearThis2,
+			ExpressionYearThis3,
+			ExpressionYearAfterNext,
+			ExpressionYearNext,
+			ExpressionYearBeforeLast2,
+			ExpressionYearBeforeLast,
+			ExpressionYearBefore2,
+			ExpressionYearBefore,
+			ExpressionThis1,
+			ExpressionNext1,
+			ExpressionUpcoming1,
+			ExpressionUpcoming2,
+			ExpressionPrevious1,
+			ExpressionPrevious2,
+		}, "|"),
+		holidayNamesExpression(),
+		ExpressionHolidays1,
+		boundaryParticles,
+	))
+	dateBirthYearRe1 = regexp.MustCompile(fmt.Sprintf(`(?:[%s])?(\d{4}|\d{2})\s*[%s]\s*%s`,
+		strings.Join([]string{
+			ExpressionYearAbbreviation1,
+			ExpressionYearAbbreviation2,
+			ExpressionYearAbbreviation3,
+		}, ""),
+		strings.Join([]string{
+			ExpressionYear1,
+			ExpressionYear2,
+		}, ""),
+		ExpressionBirth1,
+	))
+	datePartialRe1 = regexp.MustCompile(fmt.Sprintf(`(?:([%s])?(\d{2,})\s*[%s])(?:\s*(?:(\d{1,2})\s*[%s]|(%s)\s*%s|([1-4])\s*%s))?|(\d{1,2})\s*[%s]|(%s)\s*%s|([1-4])\s*%s`,
+		strings.Join([]string{
+			ExpressionYearAbbreviation1,
+			ExpressionYearAbbreviation2,
+			ExpressionYearAbbreviation3,
+		}, ""),
+		strings.Join([]string{
+			ExpressionYear1,
+			ExpressionYear2,
+		}, ""),
 		strings.Join([]string{
+			ExpressionMonth1,
+			ExpressionMonth2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionFirstHalf1,
+			ExpressionFirstHalf2,
+			ExpressionSecondHalf1,
+			ExpressionSecondHalf2,
+		}, "|"),
+		ExpressionHalf1,
+		ExpressionQuarter1,
+		strings.Join([]string{
+			ExpressionMonth1,
+			ExpressionMonth2,
+		}, ""),
+		strings.Join([]string{
+			ExpressionFirstHalf1,
+			ExpressionFirstHalf2,
+			ExpressionSecondHalf1,
+			ExpressionSecondHalf2,
+		}, "|"),
+		ExpressionHalf1,
+		ExpressionQuarter1,
+	))
+	timeRelRe1 = regexp.MustCompile(fmt.Sprintf(`((?:\d+\s*(?:%s)\s*)+)(%s)?\s*(%s)`,
+		strings.Join([]string{
+			ExpressionYear1,
+			ExpressionYear2,
+			ExpressionYear3,
+			ExpressionMonth3,
+			ExpressionMonth4,
+			ExpressionWeek2,
+			ExpressionWeek1,
+			ExpressionDay1,
+			ExpressionDay2,
+			dayCountUnit,
 			ExpressionTimeHour1,
 			ExpressionTimeMinute1,
 			ExpressionTimeSecond1,
 		}, "|"),
+		ExpressionMinuteThirty,
 		strings.Join([]string{
 			ExpressionBefore1,
 			ExpressionAfter1,
@@ -175,7 +659,7 @@ func init() {
 		}, "|"),
 		ExpressionMinuteThirty,
 	))
-	timeExactRe2 = regexp.MustCompile(fmt.Sprintf(`(?i)(%s)?\s*((\d{1,2})\s*[%s])\s*((\d{1,2})(\s*[%s]?(\d{1,2})\s*[%s]?)?)?`,
+	timeExactRe2 = regexp.MustCompile(fmt.Sprintf(`(?i)(%s)?\s*((\d{1,2})\s*[%s])\s*((\d{1,2})(?:\s*[%s])?(\s*%s\s*(\d{1,2})|\s*(\d{1,2})\s*[%s])?)?`,
 		strings.Join([]string{
 			ExpressionPeriodAM1,
 			ExpressionPeriodAM2,
@@ -190,435 +674,284 @@ func init() {
 		strings.Join([]string{
 			ExpressionMinute1,
 			ExpressionMinute2,
-			ExpressionMinute3,
 		}, "|"),
+		ExpressionMinute3,
 		strings.Join([]string{
-			ExpressionMinute1,
-			ExpressionMinute2,
+			ExpressionSecond1,
+			ExpressionSecond2,
+		}, "|"),
+	))
+
+	weekday := fmt.Sprintf(`[%s]`, strings.Join([]string{
+		ExpressionMonday1,
+		ExpressionTuesday1,
+		ExpressionWednesday1,
+		ExpressionThursday1,
+		ExpressionFriday1,
+		ExpressionSaturday1,
+		ExpressionSunday1,
+	}, ""))
+	// eg: '월요일', '화요일과 목요일', '월, 수, 금요일'
+	weekdays := fmt.Sprintf(`(?:%s(?:%s)?\s*(?:,|·|%s)?\s*)*%s\s*%s`,
+		weekday,
+		ExpressionWeekday1,
+		strings.Join([]string{
+			ExpressionAnd1,
+			ExpressionAnd2,
+			ExpressionAnd3,
 		}, "|"),
+		weekday,
+		ExpressionWeekday1,
+	)
+	recurrenceWeekdayRe = regexp.MustCompile(fmt.Sprintf(`(%s)(?:%s)?`, weekday, ExpressionWeekday1))
+	recurrenceTimeGapRe = regexp.MustCompile(fmt.Sprintf(`^[\s,]*(?:에|%s)?[\s,]*$`, ExpressionEvery2))
+	recurrenceDailyRe1 = regexp.MustCompile(fmt.Sprintf(`(%s|(\d+)\s*(?:%s)\s*%s)`,
+		strings.Join([]string{
+			ExpressionEveryDay1,
+			ExpressionEveryDay2,
+			ExpressionEveryDay3,
+			ExpressionEveryDay4,
+			ExpressionEveryOtherDay1,
+		}, "|"),
+		strings.Join([]string{
+			ExpressionDay1,
+			dayCountUnit,
+		}, "|"),
+		ExpressionEvery2,
+	))
+	recurrenceWeekdaysRe1 = regexp.MustCompile(fmt.Sprintf(`(%s|%s\s*%s|%s\s*%s|%s\s*%s)(?:\s*%s)?`,
+		ExpressionWeekdays1,
+		ExpressionWeekdays2,
+		ExpressionEvery2,
+		ExpressionWeekend1,
+		ExpressionEvery2,
+		ExpressionEvery1,
+		ExpressionWeekend1,
+		ExpressionEvery2,
 	))
+	recurrenceWeeklyRe1 = regexp.MustCompile(fmt.Sprintf(`(?:(%s)|(\d+)\s*(?:%s)\s*%s)\s*(%s)?(?:\s*%s)?|(%s)\s*%s`,
+		strings.Join([]string{
+			ExpressionEveryWeek1,
+			ExpressionEveryOtherWeek1,
+		}, "|"),
+		strings.Join([]string{
+			ExpressionWeek2,
+			ExpressionWeek1,
+		}, "|"),
+		ExpressionEvery2,
+		weekdays,
+		ExpressionEvery2,
+		weekdays,
+		ExpressionEvery2,
+	))
+	recurrenceMonthlyRe1 = regexp.MustCompile(fmt.Sprintf(`(?:(%s)|(\d+)\s*(?:%s)\s*%s)\s*(?:(\d{1,2})\s*%s|(%s|%s\s*%s)|(?:(%s)\s*(?:%s\s*)?%s|(%s)|(%s))\s*(?:%s\s*)?(%s)%s)?(?:\s*%s)?`,
+		strings.Join([]string{
+			ExpressionEveryMonth1,
+			ExpressionEveryMonth2,
+			ExpressionEveryMonth3,
+		}, "|"),
+		strings.Join([]string{
+			ExpressionMonth3,
+			ExpressionMonth4,
+		}, "|"),
+		ExpressionEvery2,
+		ExpressionDay1,
+		ExpressionLastDay1,
+		ExpressionLast1,
+		ExpressionDay3,
+		ordinalsExpression(),
+		ExpressionOrdinal2,
+		ExpressionOrdinal1,
+		ExpressionFirst1,
+		ExpressionLast1,
+		ExpressionWeek1,
+		weekday,
+		ExpressionWeekday1,
+		ExpressionEvery2,
+	))
+	recurrenceYearlyRe1 = regexp.MustCompile(fmt.Sprintf(`(?:%s)\s*(?:(\d{1,2})\s*%s\s*(\d{1,2})\s*%s)?(?:\s*%s)?`,
+		strings.Join([]string{
+			ExpressionEveryYear1,
+			ExpressionEveryYear2,
+			ExpressionEveryYear3,
+		}, "|"),
+		ExpressionMonth1,
+		ExpressionDay1,
+		ExpressionEvery2,
+	))
+
+	_defaultParser.Store(NewParser(WithLogger(verboseLogger{})))
 }
 
-// SetLocation sets location
+// returns the default parser
+func defaultParser() *Parser {
+	return _defaultParser.Load().(*Parser)
+}
+
+// SetLocation sets location of the default parser
 // 지역 설정 (timezone)
 //
 // https://golang.org/pkg/time/#Location
 func SetLocation(str string) error {
-	var err error
+	location, err := time.LoadLocation(str)
+	if err == nil {
+		_defaultParserLock.Lock()
+		defer _defaultParserLock.Unlock()
 
-	_location, err = time.LoadLocation(str)
+		_defaultParser.Store(defaultParser().with(WithLocation(location)))
+	}
 
 	return err
 }
 
-// ExtractDates extracts all dates from given string
+// ExtractDateMatches extracts all dates with their positions from given string with the default parser
+//
+// returns `nil` matches on error
+func ExtractDateMatches(str string, ifEmptyFillAsToday bool, opts ...Option) (matches []DateMatch, err error) {
+	return defaultParser().with(opts...).ExtractDateMatches(str, ifEmptyFillAsToday)
+}
+
+// ExtractDates extracts all dates from given string with the default parser
 //
 // returns `nil` dates on error
 //
-// priority of regexs is:
-//   dateRelRe1 > dateRelRe2 > dateExactRe1 > dateExactRe2
-func ExtractDates(str string, ifEmptyFillAsToday bool) (dates map[string]time.Time, err error) {
-	// initialize values
-	dates = map[string]time.Time{}
-	var year, month, day int = 0, 0, 0
-
-	// indices of processed matches: not to extract duplicated matches
-	alreadyProcessed := map[int]struct{}{}
-
-	var matches []string
-	matches = dateRelRe1.FindAllString(str, -1)
-	if matches != nil {
-		for _, match := range matches {
-			// skip already processed string
-			index := strings.Index(str, match)
-			if _, exists := alreadyProcessed[index]; exists {
-				continue
-			}
-			alreadyProcessed[index] = struct{}{} // mark it as 'already processed'
-
-			slices := dateRelRe1.FindStringSubmatch(match)
-
-			debugPrint("dateRelRe1: matched string = '%s', slices = [%s]", match, strings.Join(slices, ", "))
-
-			date := time.Now() // today
-
-			number, _ := strconv.ParseInt(slices[1], 10, 16)
-
-			multiply := 1
-			switch slices[3] {
-			case ExpressionBefore1: // before
-				multiply = -1
-			case ExpressionAfter1, ExpressionAfter2: // after
-				// do nothing (+1)
-			}
-			switch slices[2] {
-			case ExpressionYear1, ExpressionYear2: // year
-				date = date.AddDate(multiply*int(number), 0, 0)
-			case ExpressionMonth1, ExpressionMonth3: // month
-				date = date.AddDate(0, multiply*int(number), 0)
-			case ExpressionDay1, ExpressionDay2: // day
-				date = date.AddDate(0, 0, multiply*int(number))
-			default:
-				// do nothing
-			}
-
-			year, month, day = date.Year(), int(date.Month()), date.Day()
-			if ifEmptyFillAsToday {
-				year, month, _ = fillEmptyYearMonthDay(year, month, day)
-			}
-
-			debugPrint("dateRelRe1: extracted ymd = %04d-%02d-%02d", year, month, day)
-
-			// append extracted date
-			dates[match] = time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, _location)
-		}
-	}
-	matches = dateRelRe2.FindAllString(str, -1)
-	if matches != nil {
-		for _, match := range matches {
-			// skip already processed string
-			index := strings.Index(str, match)
-			if _, exists := alreadyProcessed[index]; exists {
-				continue
-			}
-			alreadyProcessed[index] = struct{}{} // mark it as 'already processed'
-
-			slices := dateRelRe2.FindStringSubmatch(match)
-
-			debugPrint("dateRelRe2: matched string = '%s', slices = [%s]", match, strings.Join(slices, ", "))
-
-			match := slices[0] // take the first slice
-
-			date := time.Now() // today
-
-			switch match {
-			case ExpressionYearBefore: // 1 year before
-				date = date.AddDate(-1, 0, 0)
-			case ExpressionYearBeforeLast: // 2 years before
-				date = date.AddDate(-2, 0, 0)
-			case ExpressionYearNext: // 1 year after
-				date = date.AddDate(1, 0, 0)
-			case ExpressionYearAfterNext: // 2 years after
-				date = date.AddDate(2, 0, 0)
-			case ExpressionTheDayBeforeYesterday1, ExpressionTheDayBeforeYesterday2: // 2 days before
-				date = date.AddDate(0, 0, -2)
-			case ExpressionYesterday1, ExpressionYesterday2: // 1 day before
-				date = date.AddDate(0, 0, -1)
-			case ExpressionToday1, ExpressionToday2: // today
-				// do nothing (= today)
-			case ExpressionTomorrow1, ExpressionTomorrow2: // 1 day after
-				date = date.AddDate(0, 0, 1)
-			case ExpressionTheDayAfterTomorrow1: // 2 days after
-				date = date.AddDate(0, 0, 2)
-			case ExpressionTwoDaysAfterTomorrow1: // 3 days after
-				date = date.AddDate(0, 0, 3)
-			default:
-				// do nothing
-			}
-
-			year, month, day = date.Year(), int(date.Month()), date.Day()
-			if ifEmptyFillAsToday {
-				year, month, _ = fillEmptyYearMonthDay(year, month, day)
-			}
-
-			debugPrint("dateRelRe2: extracted ymd = %04d-%02d-%02d", year, month, day)
-
-			// append extracted date
-			dates[match] = time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, _location)
-		}
-	}
-	matches = dateExactRe1.FindAllString(str, -1)
-	if matches != nil {
-		for _, match := range matches {
-			// skip already processed string
-			index := strings.Index(str, match)
-			if _, exists := alreadyProcessed[index]; exists {
-				continue
-			}
-			alreadyProcessed[index] = struct{}{} // mark it as 'already processed'
-
-			slices := dateExactRe1.FindStringSubmatch(match)
-
-			debugPrint("dateExactRe1: matched string = '%s', slices = [%s]", match, strings.Join(slices, ", "))
-
-			year64, _ := strconv.ParseInt(slices[2], 10, 16)
-			month64, _ := strconv.ParseInt(slices[4], 10, 16)
-			day64, _ := strconv.ParseInt(slices[5], 10, 16)
-			year, month, day = int(year64), int(month64), int(day64)
-			if ifEmptyFillAsToday {
-				year, month, _ = fillEmptyYearMonthDay(year, month, day)
-			}
-
-			debugPrint("dateExactRe1: extracted ymd = %04d-%02d-%02d", year, month, day)
-
-			// append extracted date
-			dates[match] = time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, _location)
-		}
-	}
-	matches = dateExactRe2.FindAllString(str, -1)
-	if matches != nil {
-		for _, match := range matches {
-			// skip already processed string
-			index := strings.Index(str, match)
-			if _, exists := alreadyProcessed[index]; exists {
-				continue
-			}
-			alreadyProcessed[index] = struct{}{} // mark it as 'already processed'
-
-			slices := dateExactRe2.FindStringSubmatch(match)
-
-			debugPrint("dateExactRe2: matched string = '%s', slices = [%s]", match, strings.Join(slices, ", "))
-
-			year64, _ := strconv.ParseInt(slices[2], 10, 16)
-			month64, _ := strconv.ParseInt(slices[4], 10, 16)
-			day64, _ := strconv.ParseInt(slices[5], 10, 16)
-			year, month, day = int(year64), int(month64), int(day64)
-			if ifEmptyFillAsToday {
-				year, month, _ = fillEmptyYearMonthDay(year, month, day)
-			}
-
-			debugPrint("dateExactRe2: extracted ymd = %04d-%02d-%02d", year, month, day)
-
-			// append extracted date
-			dates[match] = time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, _location)
-		}
-	}
-
-	if len(dates) <= 0 {
-		return nil, fmt.Errorf("해당하는 날짜 표현이 없습니다: '%s'", str)
-	}
-
-	return dates, nil
+// overlapping matches are resolved by their lengths (the longest one wins),
+// then by the priority of regexs:
+//
+//	dateRelRe1 > dateRelRe2 > dateWeekdayRe1 > dateRelMonthRe1 > dateRelWeekRe1 > dateRelYearRe1 > dateOrdinalRe1 > dateLunarRe1 > dateHolidayRe1 > dateBirthYearRe1 > dateExactRe1 > dateExactRe2 > datePartialRe1
+//
+// relative dates are calculated from the reference time, which can be set with `opts`
+// (eg: WithReferenceTime)
+func ExtractDates(str string, ifEmptyFillAsToday bool, opts ...Option) (dates map[string]time.Time, err error) {
+	return defaultParser().with(opts...).ExtractDates(str, ifEmptyFillAsToday)
 }
 
-// ExtractDate extracts date from given string
+// ExtractDate extracts date from given string with the default parser
 //
 // 주어진 한글 string으로부터 패턴에 가장 먼저 맞는 날짜값 추출
-func ExtractDate(str string, ifEmptyFillAsToday bool) (date time.Time, err error) {
-	var dates map[string]time.Time
-	dates, err = ExtractDates(str, ifEmptyFillAsToday)
-
-	if err != nil {
-		return time.Time{}, err
-	}
-
-	// get the left-most(with the least index) matched date
-	var index int
-	minIndex := len(str)
-	for s, d := range dates {
-		index = strings.Index(str, s)
-		if index < minIndex {
-			minIndex = index
-			date = d
-		}
-	}
+func ExtractDate(str string, ifEmptyFillAsToday bool, opts ...Option) (date time.Time, err error) {
+	return defaultParser().with(opts...).ExtractDate(str, ifEmptyFillAsToday)
+}
 
-	return date, nil
+// ExtractTimeMatches extracts all times with their positions from given string with the default parser
+//
+// returns `nil` matches on error
+func ExtractTimeMatches(str string, ifEmptyFillAsNow bool, opts ...Option) (matches []TimeMatch, err error) {
+	return defaultParser().with(opts...).ExtractTimeMatches(str, ifEmptyFillAsNow)
 }
 
-// ExtractTimes extracts all times from given string
+// ExtractTimes extracts all times from given string with the default parser
 //
 // returns `nil` times on error
 //
-// priority of regexs is:
-//   timeRelRe1 > timeExactRe1 > timeExactRe2
+// overlapping matches are resolved by their lengths (the longest one wins),
+// then by the priority of regexs:
+//
+//	timeRelRe1 > timeExactRe1 > timeExactRe2 > timeDayPeriodRe1
+//
+// relative times are calculated from the reference time, which can be set with `opts`
+// (eg: WithReferenceTime)
 //
 // 주어진 한글 string으로부터 시간 추출
-func ExtractTimes(str string, ifEmptyFillAsNow bool) (hmss map[string]Hms, err error) {
-	// initialize values
-	hmss = map[string]Hms{}
-	var parseError error
-
-	// indices of processed matches: not to extract duplicated matches
-	alreadyProcessed := map[int]struct{}{}
-
-	var matches []string
-
-	// relative time
-	matches = timeRelRe1.FindAllString(str, -1)
-	if matches != nil {
-		for _, match := range matches {
-			// skip already processed string
-			index := strings.Index(str, match)
-			if _, exists := alreadyProcessed[index]; exists {
-				continue
-			}
-			alreadyProcessed[index] = struct{}{} // mark it as 'already processed'
-
-			slices := timeRelRe1.FindStringSubmatch(match)
-
-			debugPrint("timeRelRe1: matched string = '%s', slices = [%s]", match, strings.Join(slices, ", "))
-
-			now := time.Now() // now
-
-			var number int64
-			if number, parseError = strconv.ParseInt(slices[1], 10, 16); parseError != nil {
-				continue
-			}
-			multiply := 1
-			switch slices[3] {
-			case ExpressionBefore1: // before
-				multiply = -1
-			case ExpressionAfter1, ExpressionAfter2: // after
-				// do nothing (+1)
-			}
-
-			var when time.Time
-
-			switch slices[2] {
-			case ExpressionTimeHour1: // hour
-				when = now.Add(time.Duration(multiply) * time.Duration(number) * time.Hour)
-			case ExpressionTimeMinute1: // minute
-				when = now.Add(time.Duration(multiply) * time.Duration(number) * time.Minute)
-			case ExpressionTimeSecond1: // second
-				when = now.Add(time.Duration(multiply) * time.Duration(number) * time.Second)
-			}
-
-			debugPrint("timeRelRe1: extracted hms = %02d:%02d:%02d", when.Hour(), when.Minute(), when.Second())
-
-			// append extracted time
-			hmss[match] = Hms{Hours: when.Hour(), Minutes: when.Minute(), Seconds: when.Second(), NumDaysChanged: when.Day() - now.Day(), Ambiguous: false}
-		}
-	}
+func ExtractTimes(str string, ifEmptyFillAsNow bool, opts ...Option) (hmss map[string]Hms, err error) {
+	return defaultParser().with(opts...).ExtractTimes(str, ifEmptyFillAsNow)
+}
 
-	// exact time (pattern 1)
-	matches = timeExactRe1.FindAllString(str, -1)
-	if matches != nil {
-		for _, match := range matches {
-			// skip already processed string
-			index := strings.Index(str, match)
-			if _, exists := alreadyProcessed[index]; exists {
-				continue
-			}
-			alreadyProcessed[index] = struct{}{} // mark it as 'already processed'
-
-			slices := timeExactRe1.FindStringSubmatch(match)
-
-			debugPrint("timeExactRe1: matched string = '%s', slices = [%s]", match, strings.Join(slices, ", "))
-
-			var hour64 int64
-			now := time.Now()
-			if hour64, parseError = strconv.ParseInt(slices[3], 10, 16); parseError != nil && ifEmptyFillAsNow {
-				hour64 = int64(now.Hour())
-			}
-
-			ambiguous := false
-			ampm := slices[1]
-			if strings.EqualFold(ampm, ExpressionPeriodPM1) || strings.EqualFold(ampm, ExpressionPeriodPM2) {
-				if hour64 <= 12 {
-					hour64 += 12
-				}
-			} else if !strings.EqualFold(ampm, ExpressionPeriodAM1) && !strings.EqualFold(ampm, ExpressionPeriodAM2) {
-				if hour64 < 12 {
-					ambiguous = true
-				}
-			}
-
-			debugPrint("timeExactRe1: extracted hms = %02d:%02d:%02d", hour64, 30, 0)
-
-			// append extracted time
-			hmss[match] = Hms{Hours: int(hour64), Minutes: 30, Seconds: 0, NumDaysChanged: 0, Ambiguous: ambiguous}
-		}
-	}
+// ExtractTime extracts time from given string with the default parser
+//
+// 주어진 한글 string으로부터 패턴에 가장 '먼저' 맞는 시간값 추출
+func ExtractTime(str string, ifEmptyFillAsNow bool, opts ...Option) (hms Hms, err error) {
+	return defaultParser().with(opts...).ExtractTime(str, ifEmptyFillAsNow)
+}
 
-	// exact time (pattern 2)
-	matches = timeExactRe2.FindAllString(str, -1)
-	if matches != nil {
-		for _, match := range matches {
-			// skip already processed string
-			index := strings.Index(str, match)
-			if _, exists := alreadyProcessed[index]; exists {
-				continue
-			}
-			alreadyProcessed[index] = struct{}{} // mark it as 'already processed'
-
-			slices := timeExactRe2.FindStringSubmatch(match)
-
-			debugPrint("timeExactRe2: matched string = '%s', slices = [%s]", match, strings.Join(slices, ", "))
-
-			var hour64, minute64, second64 int64 = 0, 0, 0
-			now := time.Now()
-			if hour64, parseError = strconv.ParseInt(slices[3], 10, 16); parseError != nil && ifEmptyFillAsNow {
-				hour64 = int64(now.Hour())
-			}
-			if minute64, parseError = strconv.ParseInt(slices[5], 10, 16); parseError != nil && ifEmptyFillAsNow {
-				minute64 = int64(now.Minute())
-			}
-			if second64, parseError = strconv.ParseInt(slices[7], 10, 16); parseError != nil && ifEmptyFillAsNow {
-				second64 = int64(now.Second())
-			}
-
-			ambiguous := false
-			ampm := slices[1]
-			if strings.EqualFold(ampm, ExpressionPeriodPM1) || strings.EqualFold(ampm, ExpressionPeriodPM2) {
-				if hour64 <= 12 {
-					hour64 += 12
-				}
-			} else if !strings.EqualFold(ampm, ExpressionPeriodAM1) && !strings.EqualFold(ampm, ExpressionPeriodAM2) {
-				if hour64 < 12 {
-					ambiguous = true
-				}
-			}
-
-			debugPrint("timeExactRe2: extracted hms = %02d:%02d:%02d", hour64, minute64, second64)
-
-			// append extracted time
-			hmss[match] = Hms{Hours: int(hour64), Minutes: int(minute64), Seconds: int(second64), NumDaysChanged: 0, Ambiguous: ambiguous}
-		}
-	}
+// ExtractDateTimeMatches extracts all dates with times and their positions from given string with the default parser
+//
+// returns `nil` matches on error
+func ExtractDateTimeMatches(str string, ifEmptyFillAsNow bool, opts ...Option) (matches []DateTimeMatch, err error) {
+	return defaultParser().with(opts...).ExtractDateTimeMatches(str, ifEmptyFillAsNow)
+}
 
-	if len(hmss) <= 0 {
-		return nil, fmt.Errorf("해당하는 시간 패턴이 없습니다: %s", str)
-	}
+// ExtractDateTimes extracts all dates with times from given string with the default parser
+//
+// returns `nil` datetimes on error
+//
+// 주어진 한글 string으로부터 날짜+시간 추출
+func ExtractDateTimes(str string, ifEmptyFillAsNow bool, opts ...Option) (datetimes map[string]time.Time, err error) {
+	return defaultParser().with(opts...).ExtractDateTimes(str, ifEmptyFillAsNow)
+}
 
-	return hmss, nil
+// ExtractDateTime extracts date with time from given string with the default parser
+//
+// 주어진 한글 string으로부터 패턴에 가장 먼저 맞는 날짜+시간값 추출
+func ExtractDateTime(str string, ifEmptyFillAsNow bool, opts ...Option) (datetime time.Time, err error) {
+	return defaultParser().with(opts...).ExtractDateTime(str, ifEmptyFillAsNow)
 }
 
-// ExtractTime extracts time from given string
+// ExtractRangeMatches extracts all ranges of dates/times and their positions from given string with the default parser
 //
-// 주어진 한글 string으로부터 패턴에 가장 '먼저' 맞는 시간값 추출
-func ExtractTime(str string, ifEmptyFillAsNow bool) (hms Hms, err error) {
-	var times map[string]Hms
-	times, err = ExtractTimes(str, ifEmptyFillAsNow)
+// returns `nil` matches on error
+func ExtractRangeMatches(str string, ifEmptyFillAsNow bool, opts ...Option) (matches []RangeMatch, err error) {
+	return defaultParser().with(opts...).ExtractRangeMatches(str, ifEmptyFillAsNow)
+}
 
-	if err != nil {
-		return Hms{}, err
-	}
+// ExtractRanges extracts all ranges of dates/times from given string with the default parser
+//
+// returns `nil` ranges on error
+//
+// 주어진 한글 string으로부터 기간 추출
+func ExtractRanges(str string, ifEmptyFillAsNow bool, opts ...Option) (ranges map[string]Range, err error) {
+	return defaultParser().with(opts...).ExtractRanges(str, ifEmptyFillAsNow)
+}
 
-	// get the left-most(with the least index) matched time
-	var index int
-	minIndex := len(str)
-	for s, d := range times {
-		index = strings.Index(str, s)
-		if index < minIndex {
-			minIndex = index
-			hms = d
-		}
-	}
+// ExtractRange extracts a range of dates/times from given string with the default parser
+//
+// 주어진 한글 string으로부터 패턴에 가장 먼저 맞는 기간 추출
+func ExtractRange(str string, ifEmptyFillAsNow bool, opts ...Option) (r Range, err error) {
+	return defaultParser().with(opts...).ExtractRange(str, ifEmptyFillAsNow)
+}
 
-	return hms, nil
+// ExtractDurationMatches extracts all durations and their positions from given string with the default parser
+//
+// returns `nil` matches on error
+func ExtractDurationMatches(str string, opts ...Option) (matches []DurationMatch, err error) {
+	return defaultParser().with(opts...).ExtractDurationMatches(str)
 }
 
-// 주어진 연/월/일이 0  이하일 경우 '오늘' 날짜 기준으로 값을 채워줌
-func fillEmptyYearMonthDay(year, month, day int) (int, int, int) {
-	today := time.Now()
+// ExtractDurations extracts all durations from given string with the default parser
+//
+// returns `nil` durations on error
+//
+// 주어진 한글 string으로부터 기간(길이) 추출
+func ExtractDurations(str string, opts ...Option) (durations map[string]Duration, err error) {
+	return defaultParser().with(opts...).ExtractDurations(str)
+}
 
-	if year <= 0 {
-		year = int(today.Year())
-	}
-	if month <= 0 {
-		month = int(today.Month())
-	}
-	if day <= 0 {
-		day = int(today.Day())
-	}
+// ExtractDuration extracts duration from given string with the default parser
+//
+// 주어진 한글 string으로부터 패턴에 가장 먼저 맞는 기간(길이) 추출
+func ExtractDuration(str string, opts ...Option) (duration Duration, err error) {
+	return defaultParser().with(opts...).ExtractDuration(str)
+}
 
-	return year, month, day
+// ExtractRecurrenceMatches extracts all recurrences and their positions from given string with the default parser
+//
+// returns `nil` matches on error
+func ExtractRecurrenceMatches(str string, opts ...Option) (matches []RecurrenceMatch, err error) {
+	return defaultParser().with(opts...).ExtractRecurrenceMatches(str)
 }
 
-// print debug messages
-func debugPrint(format string, v ...interface{}) {
-	if Verbose {
-		log.Printf(format, v...)
-	}
+// ExtractRecurrences extracts all recurrences from given string with the default parser
+//
+// returns `nil` recurrences on error
+//
+// 주어진 한글 string으로부터 반복 일정 추출
+func ExtractRecurrences(str string, opts ...Option) (recurrences map[string]Recurrence, err error) {
+	return defaultParser().with(opts...).ExtractRecurrences(str)
+}
+
+// ExtractRecurrence extracts recurrence from given string with the default parser
+//
+// 주어진 한글 string으로부터 패턴에 가장 먼저 맞는 반복 일정 추출
+func ExtractRecurrence(str string, opts ...Option) (recurrence Recurrence, err error) {
+	return defaultParser().with(opts...).ExtractRecurrence(str)
 }
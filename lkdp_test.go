@@ -4,7 +4,9 @@ package lkdp
 // $ go test -bench=.
 
 import (
+	"fmt"
 	"testing"
+	"time"
 )
 
 func TestExtractDate(t *testing.T) {
@@ -111,6 +113,46 @@ func TestExtractTimes(t *testing.T) {
 	}
 }
 
+func TestReferenceTime(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2019, 3, 1, 23, 30, 0, 0, loc)
+
+	for str, expected := range map[string]string{
+		`어제 올린 글`:       `2019-02-28`,
+		`내일 모여요`:        `2019-03-02`,
+		`3일 후에 봅시다`:     `2019-03-04`,
+		`1년 전 오늘`:       `2018-03-01`,
+		`12월 25일 크리스마스`: `2019-12-25`,
+	} {
+		if d, err := ExtractDate(str, true, WithReferenceTime(ref)); err == nil {
+			if d.Format("2006-01-02") != expected {
+				t.Errorf("ExtractDate extracted date: %s from string: '%s' (expected: %s)", d.Format("2006-01-02"), str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDate failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	for str, expected := range map[string]string{
+		`5분 뒤`:  `23:35:00`,
+		`1시간 전`: `22:30:00`,
+	} {
+		if hms, err := ExtractTime(str, true, WithReferenceTime(ref)); err == nil {
+			if extracted := fmt.Sprintf("%02d:%02d:%02d", hms.Hours, hms.Minutes, hms.Seconds); extracted != expected {
+				t.Errorf("ExtractTime extracted time: %s from string: '%s' (expected: %s)", extracted, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractTime failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// with a custom clock
+	clock := ClockFunc(func() time.Time { return ref })
+	if d, err := ExtractDate(`모레`, true, WithClock(clock)); err != nil || d.Format("2006-01-02") != `2019-03-03` {
+		t.Errorf("ExtractDate failed with custom clock: %s (error: %v)", d.Format("2006-01-02"), err)
+	}
+}
+
 func BenchmarkExtractDate(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = ExtractDate(`아무도 알고 싶어하진 않지만, 내 생일은 1981년 06월 02일이다.`, true)
@@ -0,0 +1,198 @@
+package lkdp
+
+// 음력 <=> 양력 변환
+//
+// 1900년 ~ 2100년 (음력) 범위를 지원
+
+import (
+	"fmt"
+	"time"
+)
+
+// supported range of lunar years
+const (
+	LunarYearMin = 1900
+	LunarYearMax = 2100
+)
+
+// lunar year information from 1900 to 2100
+//
+// bits 0~3: leap month (0 = no leap month)
+// bits 4~15: whether each month (12 ~ 1) has 30 days (1) or 29 days (0)
+// bit 16: whether the leap month has 30 days (1) or 29 days (0)
+//
+// (values are of the korean lunar calendar in korean standard time, which differs from the chinese one
+// when a new moon or a principal term falls on different dates in korea and china, eg: 설날 of 1997 = 02-08, not 02-07)
+var lunarInfo = []int{
+	0x04bd8, 0x04ae0, 0x0a570, 0x054e5, 0x0d2a0, 0x0e950, 0x16554, 0x056a0, 0x0aad0, 0x055d2, // 1900 ~ 1909
+	0x04ae0, 0x0a5d6, 0x0a4d0, 0x0d250, 0x0da95, 0x0b550, 0x056a0, 0x0ada2, 0x095d0, 0x04bb7, // 1910 ~ 1919
+	0x049b0, 0x0a4b0, 0x0b4b5, 0x06a90, 0x0ad40, 0x0bb54, 0x02b60, 0x095b0, 0x05372, 0x04970, // 1920 ~ 1929
+	0x06566, 0x0e4a0, 0x0ea50, 0x16a95, 0x05b50, 0x02b60, 0x18ae3, 0x092e0, 0x1c8d7, 0x0c950, // 1930 ~ 1939
+	0x0d4a0, 0x1d8a6, 0x0b690, 0x056d0, 0x125b4, 0x025d0, 0x092d0, 0x0d2b2, 0x0a950, 0x0d557, // 1940 ~ 1949
+	0x0b4a0, 0x0b550, 0x15555, 0x04db0, 0x025b0, 0x18573, 0x052b0, 0x0a9b8, 0x06950, 0x06aa0, // 1950 ~ 1959
+	0x0aea6, 0x0ab50, 0x04b60, 0x0aae4, 0x0a570, 0x05270, 0x07263, 0x0d950, 0x06b57, 0x056a0, // 1960 ~ 1969
+	0x09ad0, 0x04dd5, 0x04ae0, 0x0a4e0, 0x0d4d4, 0x0d250, 0x0d598, 0x0b540, 0x0d6a0, 0x195a6, // 1970 ~ 1979
+	0x095b0, 0x049b0, 0x0a9b4, 0x0a4b0, 0x0b27a, 0x06a50, 0x06d40, 0x0b756, 0x02b60, 0x095b0, // 1980 ~ 1989
+	0x04b75, 0x04970, 0x064b0, 0x074a3, 0x0ea50, 0x06d98, 0x05ad0, 0x02b60, 0x096e5, 0x092e0, // 1990 ~ 1999
+	0x0c960, 0x0e954, 0x0d4a0, 0x0da50, 0x07552, 0x056c0, 0x0abb7, 0x025d0, 0x092d0, 0x0cab5, // 2000 ~ 2009
+	0x0a950, 0x0b4a0, 0x1b4a3, 0x0b550, 0x055d9, 0x04ba0, 0x0a5b0, 0x05575, 0x052b0, 0x0a950, // 2010 ~ 2019
+	0x0b954, 0x06aa0, 0x0ad50, 0x06b52, 0x04b60, 0x0a6e6, 0x0a570, 0x05270, 0x06a65, 0x0d930, // 2020 ~ 2029
+	0x05aa0, 0x0b6a3, 0x096d0, 0x04afb, 0x04ae0, 0x0a4d0, 0x1d0d6, 0x0d250, 0x0d520, 0x0dd45, // 2030 ~ 2039
+	0x0b6a0, 0x096d0, 0x055b2, 0x049b0, 0x0a577, 0x0a4b0, 0x0b250, 0x1b255, 0x06d40, 0x0ada0, // 2040 ~ 2049
+	0x18b63, 0x09570, 0x14978, 0x04970, 0x064b0, 0x168a6, 0x0ea50, 0x06b20, 0x1aac4, 0x0ab60, // 2050 ~ 2059
+	0x09370, 0x052e3, 0x0c960, 0x0d557, 0x0d4a0, 0x0da50, 0x05d55, 0x056a0, 0x0aad0, 0x095d4, // 2060 ~ 2069
+	0x092d0, 0x0c9b8, 0x0a950, 0x0b4a0, 0x0b6a6, 0x0ad50, 0x055a0, 0x0aba4, 0x0a5b0, 0x052b0, // 2070 ~ 2079
+	0x0b2b3, 0x0a930, 0x07557, 0x06aa0, 0x0ad50, 0x14b55, 0x04b60, 0x0a570, 0x054f4, 0x05260, // 2080 ~ 2089
+	0x0e968, 0x0d530, 0x05aa0, 0x1aaa6, 0x096d0, 0x04ae0, 0x0aad4, 0x0a4d0, 0x0d260, 0x0f253, // 2090 ~ 2099
+	0x0d520, // 2100
+}
+
+// solar date of lunar 1900-01-01
+var lunarEpoch = time.Date(1900, 1, 31, 0, 0, 0, 0, time.UTC)
+
+// LunarDate is a date of the lunar calendar
+type LunarDate struct {
+	Year  int
+	Month int
+	Day   int
+
+	Leap bool // whether the month is a leap month (윤달) or not
+}
+
+// String returns the string representation of this lunar date (eg: '음력 2020년 윤4월 8일')
+func (d LunarDate) String() string {
+	leap := ""
+	if d.Leap {
+		leap = ExpressionLeapMonth1
+	}
+	return fmt.Sprintf("%s %d%s %s%d%s %d%s", ExpressionLunar1, d.Year, ExpressionYear1, leap, d.Month, ExpressionMonth1, d.Day, ExpressionDay1)
+}
+
+// Solar converts this lunar date into a solar date in given location (UTC if nil)
+//
+// 음력 날짜를 양력 날짜로 변환
+func (d LunarDate) Solar(location *time.Location) (time.Time, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	if d.Year < LunarYearMin || d.Year > LunarYearMax || d.Month < 1 || d.Month > 12 || d.Day < 1 {
+		return time.Time{}, newError(ErrOutOfRange, "지원하지 않는 음력 날짜입니다: %s", d)
+	}
+
+	leapMonth := lunarLeapMonth(d.Year)
+	if d.Leap && leapMonth != d.Month {
+		return time.Time{}, newError(ErrInvalidDate, "해당 연도에 윤달이 없습니다: %s", d)
+	}
+
+	var days int
+	if d.Leap {
+		days = lunarLeapMonthDays(d.Year)
+	} else {
+		days = lunarMonthDays(d.Year, d.Month)
+	}
+	if d.Day > days {
+		return time.Time{}, newError(ErrInvalidDate, "존재하지 않는 음력 날짜입니다: %s", d)
+	}
+
+	// days from the epoch
+	offset := 0
+	for year := LunarYearMin; year < d.Year; year++ {
+		offset += lunarYearDays(year)
+	}
+	for month := 1; month < d.Month; month++ {
+		offset += lunarMonthDays(d.Year, month)
+		if month == leapMonth {
+			offset += lunarLeapMonthDays(d.Year)
+		}
+	}
+	if d.Leap {
+		offset += lunarMonthDays(d.Year, d.Month)
+	}
+	offset += d.Day - 1
+
+	solar := lunarEpoch.AddDate(0, 0, offset)
+
+	return time.Date(solar.Year(), solar.Month(), solar.Day(), 0, 0, 0, 0, location), nil
+}
+
+// LunarToSolar converts given lunar date into a solar date in given location (UTC if nil)
+//
+// 음력 날짜를 양력 날짜로 변환
+func LunarToSolar(year, month, day int, leap bool, location *time.Location) (time.Time, error) {
+	return LunarDate{Year: year, Month: month, Day: day, Leap: leap}.Solar(location)
+}
+
+// SolarToLunar converts given solar date into a lunar date
+//
+// 양력 날짜를 음력 날짜로 변환
+func SolarToLunar(date time.Time) (lunar LunarDate, err error) {
+	offset := daysBetween(lunarEpoch, date)
+	if offset < 0 {
+		return LunarDate{}, newError(ErrOutOfRange, "지원하지 않는 날짜입니다: %s", date.Format("2006-01-02"))
+	}
+
+	year := LunarYearMin
+	for ; year <= LunarYearMax; year++ {
+		days := lunarYearDays(year)
+		if offset < days {
+			break
+		}
+		offset -= days
+	}
+	if year > LunarYearMax {
+		return LunarDate{}, newError(ErrOutOfRange, "지원하지 않는 날짜입니다: %s", date.Format("2006-01-02"))
+	}
+
+	leapMonth := lunarLeapMonth(year)
+	for month := 1; month <= 12; month++ {
+		days := lunarMonthDays(year, month)
+		if offset < days {
+			return LunarDate{Year: year, Month: month, Day: offset + 1}, nil
+		}
+		offset -= days
+
+		if month == leapMonth {
+			days = lunarLeapMonthDays(year)
+			if offset < days {
+				return LunarDate{Year: year, Month: month, Day: offset + 1, Leap: true}, nil
+			}
+			offset -= days
+		}
+	}
+
+	// should not reach here
+	return LunarDate{}, newError(ErrOutOfRange, "음력 변환에 실패했습니다: %s", date.Format("2006-01-02"))
+}
+
+// leap month of given lunar year (0 = no leap month)
+func lunarLeapMonth(year int) int {
+	return lunarInfo[year-LunarYearMin] & 0xf
+}
+
+// number of days of the leap month in given lunar year
+func lunarLeapMonthDays(year int) int {
+	if lunarLeapMonth(year) == 0 {
+		return 0
+	}
+	if lunarInfo[year-LunarYearMin]&0x10000 != 0 {
+		return 30
+	}
+	return 29
+}
+
+// number of days of given (non-leap) month in given lunar year
+func lunarMonthDays(year, month int) int {
+	if lunarInfo[year-LunarYearMin]&(0x10000>>uint(month)) != 0 {
+		return 30
+	}
+	return 29
+}
+
+// number of days in given lunar year
+func lunarYearDays(year int) (days int) {
+	for month := 1; month <= 12; month++ {
+		days += lunarMonthDays(year, month)
+	}
+	return days + lunarLeapMonthDays(year)
+}
@@ -0,0 +1,199 @@
+package lkdp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLunarToSolar(t *testing.T) {
+	for lunar, expected := range map[LunarDate]string{
+		{Year: 2020, Month: 1, Day: 1}:             `2020-01-25`, // 설날
+		{Year: 2021, Month: 8, Day: 15}:            `2021-09-21`, // 추석
+		{Year: 2020, Month: 4, Day: 8}:             `2020-04-30`, // 석가탄신일
+		{Year: 2020, Month: 4, Day: 8, Leap: true}: `2020-05-30`, // 윤4월
+		{Year: 2012, Month: 4, Day: 8}:             `2012-05-28`,
+		{Year: 2017, Month: 5, Day: 1, Leap: true}: `2017-06-24`, // 윤5월
+		{Year: 2023, Month: 4, Day: 8}:             `2023-05-27`,
+		{Year: 2027, Month: 1, Day: 1}:             `2027-02-07`,
+		{Year: 1981, Month: 1, Day: 1}:             `1981-02-05`,
+		{Year: 1900, Month: 1, Day: 1}:             `1900-01-31`,
+	} {
+		if d, err := lunar.Solar(time.UTC); err == nil {
+			if d.Format("2006-01-02") != expected {
+				t.Errorf("Solar converted: %s from lunar date: %s (expected: %s)", d.Format("2006-01-02"), lunar, expected)
+			}
+
+			// and back
+			if converted, err := SolarToLunar(d); err != nil || converted != lunar {
+				t.Errorf("SolarToLunar converted: %s from solar date: %s (expected: %s, error: %v)", converted, d.Format("2006-01-02"), lunar, err)
+			}
+		} else {
+			t.Errorf("Solar failed with lunar date: %s (error: %s)", lunar, err)
+		}
+	}
+
+	// invalid lunar dates
+	for _, lunar := range []LunarDate{
+		{Year: 2021, Month: 4, Day: 8, Leap: true}, // no leap month
+		{Year: 2020, Month: 1, Day: 31},
+		{Year: 1899, Month: 1, Day: 1},
+		{Year: 2101, Month: 1, Day: 1},
+	} {
+		if _, err := LunarToSolar(lunar.Year, lunar.Month, lunar.Day, lunar.Leap, time.UTC); err == nil {
+			t.Errorf("LunarToSolar should fail with lunar date: %s", lunar)
+		}
+	}
+
+	// nil location: in UTC
+	if d, err := LunarToSolar(2021, 8, 15, false, nil); err != nil || d.Format("2006-01-02") != `2021-09-21` || d.Location() != time.UTC {
+		t.Errorf("LunarToSolar converted: %s with nil location (error: %v)", d, err)
+	}
+}
+
+func TestKoreanLunarCalendar(t *testing.T) {
+	// 설날 and 추석 of the years in which the korean lunar calendar differs from the chinese one
+	for year, expected := range map[int][2]string{
+		1903: {`1903-01-29`, `1903-10-05`},
+		1904: {`1904-02-16`, `1904-09-24`},
+		1905: {`1905-02-04`, `1905-09-13`},
+		1906: {`1906-01-25`, `1906-10-02`},
+		1908: {`1908-02-02`, `1908-09-10`},
+		1911: {`1911-01-30`, `1911-10-06`},
+		1914: {`1914-01-26`, `1914-10-04`},
+		1915: {`1915-02-14`, `1915-09-23`},
+		1916: {`1916-02-04`, `1916-09-12`},
+		1918: {`1918-02-11`, `1918-09-19`},
+		1919: {`1919-02-01`, `1919-10-08`},
+		1920: {`1920-02-20`, `1920-09-26`},
+		1923: {`1923-02-16`, `1923-09-25`},
+		1924: {`1924-02-05`, `1924-09-13`},
+		1925: {`1925-01-24`, `1925-10-02`},
+		1927: {`1927-02-02`, `1927-09-10`},
+		1928: {`1928-01-23`, `1928-09-28`},
+		1931: {`1931-02-17`, `1931-09-26`},
+		1934: {`1934-02-14`, `1934-09-23`},
+		1936: {`1936-01-24`, `1936-09-30`},
+		1942: {`1942-02-15`, `1942-09-25`},
+		1943: {`1943-02-05`, `1943-09-14`},
+		1944: {`1944-01-26`, `1944-10-01`},
+		1949: {`1949-01-29`, `1949-10-06`},
+		1950: {`1950-02-17`, `1950-09-26`},
+		1952: {`1952-01-27`, `1952-10-03`},
+		1953: {`1953-02-14`, `1953-09-22`},
+		1954: {`1954-02-04`, `1954-09-11`},
+		1955: {`1955-01-24`, `1955-09-30`},
+		1957: {`1957-01-31`, `1957-09-08`},
+		1958: {`1958-02-19`, `1958-09-27`},
+		1965: {`1965-02-02`, `1965-09-10`},
+		1966: {`1966-01-22`, `1966-09-29`},
+		1968: {`1968-01-30`, `1968-10-06`},
+		1970: {`1970-02-06`, `1970-09-15`},
+		1972: {`1972-02-15`, `1972-09-22`},
+		1973: {`1973-02-03`, `1973-09-11`},
+		1976: {`1976-01-31`, `1976-09-08`},
+		1978: {`1978-02-07`, `1978-09-17`},
+		1982: {`1982-01-25`, `1982-10-01`},
+		1987: {`1987-01-29`, `1987-10-07`},
+		1988: {`1988-02-18`, `1988-09-25`},
+		1989: {`1989-02-06`, `1989-09-14`},
+		1990: {`1990-01-27`, `1990-10-03`},
+		1995: {`1995-01-31`, `1995-09-09`},
+		1996: {`1996-02-19`, `1996-09-27`},
+		1997: {`1997-02-08`, `1997-09-16`},
+		1998: {`1998-01-28`, `1998-10-05`},
+		2001: {`2001-01-24`, `2001-10-01`},
+		2005: {`2005-02-09`, `2005-09-18`},
+		2012: {`2012-01-23`, `2012-09-30`},
+		2013: {`2013-02-10`, `2013-09-19`},
+		2017: {`2017-01-28`, `2017-10-04`},
+		2019: {`2019-02-05`, `2019-09-13`},
+		2020: {`2020-01-25`, `2020-10-01`},
+		2023: {`2023-01-22`, `2023-09-29`},
+		2026: {`2026-02-17`, `2026-09-25`},
+		2027: {`2027-02-07`, `2027-09-15`},
+		2028: {`2028-01-27`, `2028-10-03`},
+		2029: {`2029-02-13`, `2029-09-22`},
+		2031: {`2031-01-23`, `2031-10-01`},
+		2034: {`2034-02-19`, `2034-09-27`},
+		2036: {`2036-01-28`, `2036-10-04`},
+		2040: {`2040-02-12`, `2040-09-21`},
+		2041: {`2041-02-01`, `2041-09-10`},
+		2046: {`2046-02-06`, `2046-09-15`},
+		2048: {`2048-02-14`, `2048-09-22`},
+		2050: {`2050-01-23`, `2050-09-30`},
+		2051: {`2051-02-11`, `2051-09-19`},
+		2052: {`2052-02-01`, `2052-09-07`},
+		2057: {`2057-02-04`, `2057-09-13`},
+		2058: {`2058-01-24`, `2058-10-02`},
+		2059: {`2059-02-12`, `2059-09-21`},
+		2060: {`2060-02-02`, `2060-09-09`},
+		2061: {`2061-01-22`, `2061-09-28`},
+		2068: {`2068-02-03`, `2068-09-11`},
+		2069: {`2069-01-23`, `2069-09-29`},
+		2070: {`2070-02-11`, `2070-09-19`},
+		2071: {`2071-01-31`, `2071-09-08`},
+		2080: {`2080-01-22`, `2080-09-28`},
+		2081: {`2081-02-09`, `2081-09-17`},
+		2082: {`2082-01-29`, `2082-10-06`},
+		2088: {`2088-01-24`, `2088-09-29`},
+		2089: {`2089-02-11`, `2089-09-19`},
+		2091: {`2091-02-18`, `2091-09-27`},
+		2092: {`2092-02-08`, `2092-09-16`},
+		2093: {`2093-01-27`, `2093-10-05`},
+		2094: {`2094-02-15`, `2094-09-24`},
+		2096: {`2096-01-25`, `2096-10-01`},
+		2097: {`2097-02-12`, `2097-09-20`},
+		2098: {`2098-02-01`, `2098-09-10`},
+		2099: {`2099-01-21`, `2099-09-29`},
+	} {
+		for i, lunar := range []LunarDate{{Year: year, Month: 1, Day: 1}, {Year: year, Month: 8, Day: 15}} {
+			if d, err := lunar.Solar(time.UTC); err != nil || d.Format("2006-01-02") != expected[i] {
+				t.Errorf("Solar converted: %s from lunar date: %s (expected: %s, error: %v)", d.Format("2006-01-02"), lunar, expected[i], err)
+			}
+		}
+	}
+}
+
+func TestExtractLunarDates(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 9, 0, 0, 0, loc)
+
+	for str, expected := range map[string]string{
+		`음력 8월 15일은 추석`:     `2021-09-21`,
+		`할머니 생신은 음력 1월 3일`:  `2021-02-14`,
+		`음력 2020년 윤4월 8일`:   `2020-05-30`,
+		`陰曆 8月 15日`:         `2021-09-21`,
+		`음력 팔월 십오일에 성묘를 가자`: `2021-09-21`,
+	} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+			if m := matches[0]; m.Date.Format("2006-01-02") != expected || m.Rule != RuleDateLunar1 {
+				t.Errorf("ExtractDateMatches extracted date: %s (rule: %s) from string: '%s' (expected: %s)", m.Date.Format("2006-01-02"), m.Rule, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// leap months without years: the nearest year with the leap month
+	for ref, expected := range map[time.Time]string{
+		ref:                                    `2020-05-30`,
+		time.Date(2019, 6, 1, 9, 0, 0, 0, loc): `2020-05-30`,
+		time.Date(2050, 1, 1, 9, 0, 0, 0, loc): `2058-05-29`,
+	} {
+		if matches, err := ExtractDateMatches(`음력 윤4월 8일`, true, WithReferenceTime(ref)); err == nil {
+			if m := matches[0]; m.Date.Format("2006-01-02") != expected || m.Rule != RuleDateLunar1 {
+				t.Errorf("ExtractDateMatches extracted date: %s (rule: %s) at: %s (expected: %s)", m.Date.Format("2006-01-02"), m.Rule, ref, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed at: %s (error: %s)", ref, err)
+		}
+	}
+
+	// invalid lunar dates are not read as solar dates
+	for _, str := range []string{`음력 2021년 윤4월 8일`, `음력 2021년 4월 30일`} {
+		if dates, err := ExtractDates(str, true, WithReferenceTime(ref)); !errors.Is(err, ErrInvalidDate) {
+			t.Errorf("ExtractDates should fail with ErrInvalidDate for string: '%s' (extracted: %v, error: %v)", str, dates, err)
+		}
+	}
+}
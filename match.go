@@ -0,0 +1,206 @@
+package lkdp
+
+import (
+	"sort"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Span is a matched text and its position in the given string
+type Span struct {
+	Text string // matched text
+
+	Start, End         int // byte offsets (str[Start:End] == Text)
+	RuneStart, RuneEnd int // rune offsets
+}
+
+// DateMatch is a date extracted from the given string
+type DateMatch struct {
+	Span
+
+	Rule string    // name of the rule which produced this match
+	Date time.Time // extracted date
+
+	Until time.Time // last date of the period (eg: '추석 연휴', '3월'), zero if it is not a period
+
+	Granularity Granularity // unit of the date (eg: GranularityMonth for '3월')
+
+	Normalized bool // whether an invalid date was normalized or not (eg: '2월 30일' => 3월 2일)
+
+	Tense Tense // tense inferred from the text around it (eg: TensePast for '1월 3일에 만났다')
+
+	missing   dateParts // parts of the date which were not given in the string (eg: year of '3월 5일')
+	inherited dateParts // missing parts which were inherited from the preceding date (eg: year of '2020년 3월 5일, 4월 2일')
+	invalid   *Error    // why the given date is invalid (nil if valid)
+	month     int       // month as given or filled, for resolving invalid dates in other years (eg: 2 of '2월 29일')
+	day       int       // day as given, for resolving invalid dates in other years (eg: 29 of '2월 29일')
+
+	ofYear func(year int) (time.Time, bool) // date in given year, for dates not on the same day in other years (eg: '3월 첫째 주 월요일')
+}
+
+// TimeMatch is a time extracted from the given string
+type TimeMatch struct {
+	Span
+
+	Rule string // name of the rule which produced this match
+	Hms  Hms    // extracted time
+
+	Normalized bool // whether an out-of-range time was normalized or not (eg: '25시 70분' => 02:10 of the next day)
+
+	Tense Tense // tense inferred from the text around it (eg: TenseFuture for '3시에 만나자')
+
+	Candidates []Hms // candidates of an ambiguous time in the order of preference (nil if not ambiguous)
+}
+
+// parts of a date
+type dateParts uint8
+
+const (
+	dateYear dateParts = 1 << iota
+	dateMonth
+	dateDay
+)
+
+// DateTimeMatch is a date with time extracted from the given string
+type DateTimeMatch struct {
+	Span
+
+	DateMatch *DateMatch // matched date (nil if no date was given)
+	TimeMatch *TimeMatch // matched time (nil if no time was given)
+
+	DateTime time.Time // extracted date with time
+
+	Candidates []time.Time // candidates of an ambiguous time in the order of preference (nil if not ambiguous)
+
+	Tense Tense // tense inferred from the text around it
+
+	anchored bool      // whether the date was inherited from the preceding one or not (eg: '3시' of '어제 오후 1시와 3시')
+	now      time.Time // reference time of the extraction (DTSTAMP of its event)
+}
+
+// RangeMatch is a range of dates/times extracted from the given string
+//
+// (eg: '3월 5일부터 7일까지', '5시 01분 ~ 15시 6분', '추석 연휴')
+type RangeMatch struct {
+	Span
+
+	From DateTimeMatch // start of the range
+	To   DateTimeMatch // end of the range (with missing parts inherited from the start)
+
+	now time.Time // reference time of the extraction (DTSTAMP of its event)
+}
+
+// DurationMatch is a duration extracted from the given string
+type DurationMatch struct {
+	Span
+
+	Duration Duration // extracted duration
+}
+
+// RecurrenceMatch is a recurrence extracted from the given string
+type RecurrenceMatch struct {
+	Span
+
+	Recurrence Recurrence // extracted recurrence
+
+	Candidates []Recurrence // candidates of a recurrence with an ambiguous time in the order of preference (nil if not ambiguous)
+
+	now time.Time // reference time of the extraction (DTSTAMP of its event)
+}
+
+// returns a new span of str[start:end] (without leading/trailing spaces)
+func newSpan(str string, start, end int) Span {
+	for start < end {
+		r, size := utf8.DecodeRuneInString(str[start:end])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		start += size
+	}
+	for end > start {
+		r, size := utf8.DecodeLastRuneInString(str[start:end])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		end -= size
+	}
+
+	runeStart := utf8.RuneCountInString(str[:start])
+
+	return Span{
+		Text:      str[start:end],
+		Start:     start,
+		End:       end,
+		RuneStart: runeStart,
+		RuneEnd:   runeStart + utf8.RuneCountInString(str[start:end]),
+	}
+}
+
+// returns submatch strings of given submatch indices
+func submatches(str string, indices []int) (slices []string) {
+	slices = make([]string, len(indices)/2)
+	for i := range slices {
+		if indices[2*i] >= 0 {
+			slices[i] = str[indices[2*i]:indices[2*i+1]]
+		}
+	}
+	return slices
+}
+
+// candidate of a match, before resolving overlaps
+type candidate struct {
+	Span
+
+	priority int // priority of the rule which produced this candidate (lower is higher)
+	index    int // index of the extracted value
+}
+
+// check if this candidate lies in one of given spans
+func (c candidate) within(spans []Span) bool {
+	for _, span := range spans {
+		if c.Start >= span.Start && c.End <= span.End {
+			return true
+		}
+	}
+	return false
+}
+
+// resolves overlapping candidates and returns the selected ones, ordered by their positions
+//
+// among overlapping candidates, the longest one wins,
+// and when they have the same length, the one with the highest priority (then the left-most one) wins
+func resolveOverlaps(candidates []candidate) (selected []candidate) {
+	sorted := make([]candidate, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		li, lj := sorted[i].RuneEnd-sorted[i].RuneStart, sorted[j].RuneEnd-sorted[j].RuneStart
+		if li != lj {
+			return li > lj
+		}
+		if sorted[i].priority != sorted[j].priority {
+			return sorted[i].priority < sorted[j].priority
+		}
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	for _, c := range sorted {
+		overlapped := false
+		for _, s := range selected {
+			if c.Start < s.End && s.Start < c.End {
+				overlapped = true
+				break
+			}
+		}
+		if !overlapped {
+			selected = append(selected, c)
+		}
+	}
+
+	// sort by positions
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].Start < selected[j].Start
+	})
+
+	return selected
+}
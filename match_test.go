@@ -0,0 +1,122 @@
+package lkdp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestExtractDateMatches(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2020, 11, 10, 9, 0, 0, 0, loc)
+
+	str := `내일 보고, 내일 또 보자. 3월 1일에도`
+	expected := []struct {
+		text       string
+		start, end int
+		runeStart  int
+		runeEnd    int
+		rule       string
+		date       string
+	}{
+		{`내일`, 0, 6, 0, 2, RuleDateRel2, `2020-11-11`},
+		{`내일`, 15, 21, 7, 9, RuleDateRel2, `2020-11-11`},
+		{`3월 1일`, 34, 43, 16, 21, RuleDateExact1, `2020-03-01`},
+	}
+
+	if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+		if len(matches) != len(expected) {
+			t.Fatalf("ExtractDateMatches extracted %d matches from string: '%s' (expected: %d)", len(matches), str, len(expected))
+		}
+		for i, m := range matches {
+			e := expected[i]
+			if m.Text != e.text || m.Start != e.start || m.End != e.end || m.RuneStart != e.runeStart || m.RuneEnd != e.runeEnd || m.Rule != e.rule || m.Date.Format("2006-01-02") != e.date {
+				t.Errorf("ExtractDateMatches extracted unexpected match: %+v (expected: %+v)", m, e)
+			}
+			if str[m.Start:m.End] != m.Text {
+				t.Errorf("ExtractDateMatches extracted match with wrong offsets: %+v", m)
+			}
+		}
+	} else {
+		t.Errorf("ExtractDateMatches failed with string: '%s' (error: %s)", str, err)
+	}
+}
+
+func TestExtractTimeMatches(t *testing.T) {
+	str := `9시 반에 일어났다가 10시에 다시 잠들었다`
+	if matches, err := ExtractTimeMatches(str, false); err == nil {
+		if len(matches) != 2 || matches[0].Text != `9시 반` || matches[1].Text != `10시` || matches[0].Rule != RuleTimeExact1 {
+			t.Errorf("ExtractTimeMatches extracted unexpected matches: %+v", matches)
+		}
+	} else {
+		t.Errorf("ExtractTimeMatches failed with string: '%s' (error: %s)", str, err)
+	}
+}
+
+func TestExtractDateTimeMatches(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2020, 11, 10, 9, 0, 0, 0, loc)
+
+	str := `내일 오후 3시, 그리고 모레`
+	if matches, err := ExtractDateTimeMatches(str, true, WithReferenceTime(ref)); err == nil {
+		if len(matches) != 2 {
+			t.Fatalf("ExtractDateTimeMatches extracted unexpected matches: %+v", matches)
+		}
+		if m := matches[0]; m.Text != `내일 오후 3시` || m.DateMatch == nil || m.TimeMatch == nil || m.DateTime.Format("2006-01-02 15:04") != `2020-11-11 15:00` {
+			t.Errorf("ExtractDateTimeMatches extracted unexpected match: %+v", m)
+		}
+		if m := matches[1]; m.Text != `모레` || m.DateMatch == nil || m.TimeMatch != nil {
+			t.Errorf("ExtractDateTimeMatches extracted unexpected match: %+v", m)
+		}
+	} else {
+		t.Errorf("ExtractDateTimeMatches failed with string: '%s' (error: %s)", str, err)
+	}
+}
+
+func TestResolveOverlaps(t *testing.T) {
+	// repeated phrases and overlapping matches of different rules
+	for str, expected := range map[string][]string{
+		`2019년 3월 1일에 3.1 만세운동(1919.03.01) 100주년이라고 알려다오`: {`2019년 3월 1일`, `3.1`, `1919.03.01`},
+		`2일 전만 해도 2일 전과 달랐다`:                              {`2일 전`, `2일 전`},
+		`6일 후면 3월 31일, 이달의 마지막 날이다`:                       {`6일 후`, `3월 31일`, `이달의 마지막 날`},
+	} {
+		if matches, err := ExtractDateMatches(str, false); err == nil {
+			texts := []string{}
+			for _, m := range matches {
+				texts = append(texts, m.Text)
+			}
+			if fmt.Sprintf("%q", texts) != fmt.Sprintf("%q", expected) {
+				t.Errorf("ExtractDateMatches extracted: %q from string: '%s' (expected: %q)", texts, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	for str, expected := range map[string][]string{
+		`1시간 전이면 몇 시일까요?`:     {`1시간 전`},
+		`9시 반에 일어나서 9시 반에 잤다`: {`9시 반`, `9시 반`},
+	} {
+		if matches, err := ExtractTimeMatches(str, false); err == nil {
+			texts := []string{}
+			for _, m := range matches {
+				texts = append(texts, m.Text)
+			}
+			if fmt.Sprintf("%q", texts) != fmt.Sprintf("%q", expected) {
+				t.Errorf("ExtractTimeMatches extracted: %q from string: '%s' (expected: %q)", texts, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractTimeMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// overlaps between dates and times
+	str := `현재 시각: 18:09:35.211 KST`
+	if matches, err := ExtractDateTimeMatches(str, false); err == nil {
+		if len(matches) != 1 || matches[0].TimeMatch == nil || matches[0].Text != `18:09:35` {
+			t.Errorf("ExtractDateTimeMatches extracted unexpected matches: %+v", matches)
+		}
+	} else {
+		t.Errorf("ExtractDateTimeMatches failed with string: '%s' (error: %s)", str, err)
+	}
+}
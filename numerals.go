@@ -0,0 +1,251 @@
+package lkdp
+
+// 한자어/고유어 숫자 표현을 아라비아 숫자로 변환
+//
+// eg: '세 시 반' => '3 시 반', '이십오일' => '25일', '이틀 후' => '2날 후'
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 한자어 숫자
+var sinoDigits = map[rune]int{
+	'일': 1, '이': 2, '삼': 3, '사': 4, '오': 5, '육': 6, '칠': 7, '팔': 8, '구': 9,
+}
+var sinoMultipliers = map[rune]int{
+	'십': 10, '백': 100, '천': 1000,
+}
+
+// 고유어 숫자 (십의 자리)
+var nativeTens = map[string]int{
+	`열`: 10, `스물`: 20, `스무`: 20, `서른`: 30, `마흔`: 40, `쉰`: 50,
+}
+
+// 고유어 숫자 (일의 자리)
+var nativeUnits = map[string]int{
+	`하나`: 1, `한`: 1,
+	`둘`: 2, `두`: 2,
+	`셋`: 3, `세`: 3, `석`: 3,
+	`넷`: 4, `네`: 4, `넉`: 4,
+	`다섯`: 5, `여섯`: 6, `일곱`: 7, `여덟`: 8, `아홉`: 9,
+}
+
+// unit of normalized day counts ('이틀' => '2날'),
+// which is not read as a day of a month by date rules (eg: '이틀 동안' is not the 2nd)
+const dayCountUnit = ExpressionDay3
+
+// 날짜 수를 나타내는 단어
+var dayCounts = map[string]int{
+	`하루`: 1, `이틀`: 2, `사흘`: 3, `나흘`: 4, `닷새`: 5, `엿새`: 6, `이레`: 7, `여드레`: 8, `아흐레`: 9, `열흘`: 10, `보름`: 15,
+}
+
+// 예외적인 월 이름
+var irregularMonths = map[string]int{
+	`유`: 6,  // 유월
+	`시`: 10, // 시월
+}
+
+// words which should not be normalized
+var numeralExceptions = []string{
+	`삼일절`,
+	`일일이`,
+	`오일장`,
+	`십일조`,
+	`한시도`,
+	`세시 풍속`,
+	`세시풍속`,
+}
+
+var numeralsRe *regexp.Regexp
+
+// text right after a single-syllable sino-korean number and its unit (eg: '오일장' => '장', '이분이' => '이'),
+// or a native number and '시' (eg: '한시적' => '적')
+var numeralBoundaryRe = regexp.MustCompile(`^(?:$|[^가-힣]|에는|에|부터|까지|쯤|이|은|의|도|마다|째|간|동안|전|후|뒤|반)`)
+
+// text right before a day (eg: '유월 이일' => '유월 ')
+var numeralMonthBeforeRe = regexp.MustCompile(fmt.Sprintf(`%s\s*$`, ExpressionMonth1))
+
+// text right after a day of a relative offset (eg: '육일 후' => ' 후')
+var numeralOffsetAfterRe = regexp.MustCompile(fmt.Sprintf(`^\s*(?:%s)`, strings.Join([]string{
+	ExpressionBefore1,
+	ExpressionAfter1,
+	ExpressionAfter2,
+}, "|")))
+
+func init() {
+	numeralsRe = regexp.MustCompile(fmt.Sprintf(`(^|[^가-힣0-9])(?:(%s)(\s*)(%s)|((?:%s)(?:%s)?|(?:%s))(\s*)(%s)|([%s]+)(\s*)(%s)|(%s)()(%s))`,
+		// day counts + (before/after/during...)
+		`하루|이틀|사흘|나흘|닷새|엿새|이레|여드레|아흐레|열흘|보름`,
+		strings.Join([]string{
+			ExpressionBefore1,
+			ExpressionAfter1,
+			ExpressionAfter2,
+			`동안`,
+			`마다`,
+			`간`,
+			`만`,
+			`째`,
+			`이내`,
+			`내`,
+		}, "|"),
+		// native numbers + units
+		`열|스물|스무|서른|마흔|쉰`,
+		`하나|한|둘|두|셋|세|석|넷|네|넉|다섯|여섯|일곱|여덟|아홉`,
+		`하나|한|둘|두|셋|세|석|넷|네|넉|다섯|여섯|일곱|여덟|아홉`,
+		strings.Join([]string{
+			ExpressionTimeHour1,
+			ExpressionHour1,
+			`달`,
+			`해`,
+			`주`,
+		}, "|"),
+		// sino-korean numbers + units
+		`일이삼사오육칠팔구십백천`,
+		strings.Join([]string{
+			ExpressionYear1,
+			ExpressionMonth3,
+			ExpressionMonth1,
+			`주일`,
+			`주`,
+			ExpressionDay1,
+			ExpressionTimeHour1,
+			ExpressionHour1,
+			ExpressionMinute1,
+			ExpressionSecond1,
+		}, "|"),
+		// irregular months
+		`유|시`,
+		ExpressionMonth1,
+	))
+}
+
+// returns the normalized string (numerals converted into digits) and byte offsets of it in the original string
+//
+// (offsets[i] = offset in `str` of the normalized string's i-th byte, len(offsets) = len(normalized) + 1)
+func normalizeNumerals(str string) (normalized string, offsets []int) {
+	var builder strings.Builder
+	offsets = make([]int, 0, len(str)+1)
+
+	// append original string str[from:to]
+	copyOriginal := func(from, to int) {
+		builder.WriteString(str[from:to])
+		for i := from; i < to; i++ {
+			offsets = append(offsets, i)
+		}
+	}
+	// replace original string str[from:to] with given number (and suffix)
+	replace := func(from, to int, number int, suffix string) {
+		replaced := strconv.Itoa(number) + suffix
+		builder.WriteString(replaced)
+		for i := 0; i < len(replaced); i++ {
+			offsets = append(offsets, from)
+		}
+	}
+
+	last := 0
+	for _, indices := range numeralsRe.FindAllStringSubmatchIndex(str, -1) {
+		if isNumeralException(str[indices[0]:]) || isNumeralException(str[indices[3]:]) {
+			continue
+		}
+
+		slices := submatches(str, indices)
+
+		var from, to, number int
+		var suffix string
+		switch {
+		case slices[2] != "": // day counts (eg: '이틀' => '2날')
+			from, to, number, suffix = indices[4], indices[5], dayCounts[slices[2]], dayCountUnit
+		case slices[5] != "": // native numbers
+			if slices[7] == ExpressionHour1 && !numeralBoundaryRe.MatchString(str[indices[1]:]) { // (eg: not '한시적')
+				continue
+			}
+			from, to, number = indices[10], indices[11], parseNativeNumber(slices[5])
+		case slices[8] != "": // sino-korean numbers
+			if utf8.RuneCountInString(slices[8]) == 1 && !isSingleSinoNumeral(str[:indices[16]], slices[9], slices[10], str[indices[1]:]) {
+				continue
+			}
+			from, to, number = indices[16], indices[17], parseSinoNumber(slices[8])
+		case slices[11] != "": // irregular months
+			from, to, number = indices[22], indices[23], irregularMonths[slices[11]]
+		default:
+			continue
+		}
+		if number <= 0 {
+			continue
+		}
+
+		copyOriginal(last, from)
+		replace(from, to, number, suffix)
+		last = to
+	}
+	copyOriginal(last, len(str))
+	offsets = append(offsets, len(str))
+
+	return builder.String(), offsets
+}
+
+// check if given string starts with an exceptional word
+func isNumeralException(str string) bool {
+	for _, exception := range numeralExceptions {
+		if strings.HasPrefix(str, exception) {
+			return true
+		}
+	}
+	return false
+}
+
+// check if a single-syllable sino-korean number with given unit is a number, not a part of another word
+//
+// (eg: not '이 시간에', '이 일은', '오일장에', days only after months or before directions: '유월 이일', '육일 후', not '사일 동안',
+// and no hours: not '일시에', '오시면')
+func isSingleSinoNumeral(before, spaces, unit, after string) bool {
+	switch {
+	case spaces != "":
+		return false
+	case !numeralBoundaryRe.MatchString(after):
+		return false
+	case unit == ExpressionDay1:
+		return numeralMonthBeforeRe.MatchString(before) || numeralOffsetAfterRe.MatchString(after)
+	case unit == ExpressionHour1:
+		return false
+	}
+	return true
+}
+
+// parse sino-korean number (eg: '이십오' => 25, '천구백팔십일' => 1981)
+//
+// returns 0 for malformed numbers (eg: '사이')
+func parseSinoNumber(str string) (number int) {
+	current := 0
+	for _, r := range str {
+		if digit, exists := sinoDigits[r]; exists {
+			if current != 0 { // consecutive digits
+				return 0
+			}
+			current = digit
+		} else if multiplier, exists := sinoMultipliers[r]; exists {
+			if current == 0 {
+				current = 1
+			}
+			number += current * multiplier
+			current = 0
+		}
+	}
+	return number + current
+}
+
+// parse native korean number (eg: '열두' => 12, '스물네' => 24)
+func parseNativeNumber(str string) (number int) {
+	for tens, value := range nativeTens {
+		if strings.HasPrefix(str, tens) {
+			number += value
+			str = strings.TrimPrefix(str, tens)
+			break
+		}
+	}
+	return number + nativeUnits[str]
+}
@@ -0,0 +1,126 @@
+package lkdp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNormalizeNumerals(t *testing.T) {
+	for str, expected := range map[string]string{
+		`세 시 반`:         `3 시 반`,
+		`열두 시`:          `12 시`,
+		`이십오일`:          `25일`,
+		`삼월 일일`:         `3월 1일`,
+		`다섯 시간 뒤`:       `5 시간 뒤`,
+		`이틀 후`:          `2날 후`,
+		`사흘 전`:          `3날 전`,
+		`보름 동안`:         `15날 동안`,
+		`이틀마다`:          `2날마다`,
+		`스물네 시간`:        `24 시간`,
+		`천구백팔십일년 유월 이일`: `1981년 6월 2일`,
+		`시월 구일`:         `10월 9일`,
+		`석 달 뒤`:         `3 달 뒤`,
+		// not numerals
+		`오늘 내일 오후`:     `오늘 내일 오후`,
+		`일요일 하루 종일`:    `일요일 하루 종일`,
+		`내일 일찍 일어나자`:   `내일 일찍 일어나자`,
+		`삼일절 기념식`:      `삼일절 기념식`,
+		`3시와 4시 사이 일정`: `3시와 4시 사이 일정`,
+		`3시 월요일`:       `3시 월요일`,
+		`한국 시간`:        `한국 시간`,
+		`이 시간에 뭐해`:     `이 시간에 뭐해`,
+		`이 일은 중요해`:     `이 일은 중요해`,
+		`오일장에 가자`:      `오일장에 가자`,
+		`사일 동안`:        `사일 동안`,
+		`한시적으로 운영합니다`:  `한시적으로 운영합니다`,
+		`한시도 잊지 않았다`:   `한시도 잊지 않았다`,
+		`세시 풍속`:        `세시 풍속`,
+		`일시에 몰려왔다`:     `일시에 몰려왔다`,
+		// sino-korean hours, and days before directions
+		`이십삼 시 오십구 분`: `23 시 59 분`,
+		`육일 후`:        `6일 후`,
+	} {
+		if normalized, offsets := normalizeNumerals(str); normalized != expected {
+			t.Errorf("normalizeNumerals normalized: '%s' from string: '%s' (expected: '%s')", normalized, str, expected)
+		} else if len(offsets) != len(normalized)+1 || offsets[len(normalized)] != len(str) {
+			t.Errorf("normalizeNumerals returned wrong offsets: %v for string: '%s'", offsets, str)
+		}
+	}
+}
+
+func TestKoreanNumerals(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2020, 11, 10, 9, 0, 0, 0, loc)
+
+	for str, expected := range map[string]string{
+		`이십오일에 만나`:      `2020-11-25`,
+		`삼월 일일`:         `2020-03-01`,
+		`이틀 후에 봐`:       `2020-11-12`,
+		`사흘 전에 봤어`:      `2020-11-07`,
+		`두 달 뒤`:         `2021-01-10`,
+		`천구백팔십일년 유월 이일`: `1981-06-02`,
+		`육일 후에 봐`:       `2020-11-16`,
+	} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+			if d := matches[0].Date; d.Format("2006-01-02") != expected {
+				t.Errorf("ExtractDateMatches extracted date: %s from string: '%s' (expected: %s)", d.Format("2006-01-02"), str, expected)
+			}
+			if m := matches[0]; str[m.Start:m.End] != m.Text {
+				t.Errorf("ExtractDateMatches extracted match with wrong offsets: %+v", m)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// not numerals
+	for _, str := range []string{
+		`한시적으로 운영합니다`,
+		`한시도 잊지 않았다`,
+		`세시 풍속`,
+		`이 시간에 뭐해`,
+		`이 일은 중요해`,
+		`오일장에 가자`,
+		`사일 동안`,
+	} {
+		if dates, err := ExtractDateMatches(str, false, WithReferenceTime(ref)); err == nil {
+			t.Errorf("ExtractDateMatches should fail with string: '%s' (extracted: %+v)", str, dates)
+		}
+		if times, err := ExtractTimeMatches(str, false, WithReferenceTime(ref)); err == nil {
+			t.Errorf("ExtractTimeMatches should fail with string: '%s' (extracted: %+v)", str, times)
+		}
+	}
+
+	// day counts are not days of months
+	for _, str := range []string{
+		`이틀 동안 쉬었다`,
+		`열흘 만에`,
+		`보름 동안`,
+		`사흘간 비가 왔다`,
+	} {
+		if dates, err := ExtractDates(str, true, WithReferenceTime(ref)); err == nil {
+			t.Errorf("ExtractDates should fail with string: '%s' (extracted: %+v)", str, dates)
+		}
+	}
+
+	for str, expected := range map[string]string{
+		`세 시 반에 보자`:   `03:30:00`,
+		`열두 시`:        `12:00:00`,
+		`오후 열 시 삼십 분`: `22:30:00`,
+		`다섯 시간 뒤`:     `14:00:00`,
+		`이십삼 시 오십구 분`: `23:59:00`,
+	} {
+		if matches, err := ExtractTimeMatches(str, false, WithReferenceTime(ref)); err == nil {
+			hms := matches[0].Hms
+			if extracted := fmt.Sprintf("%02d:%02d:%02d", hms.Hours, hms.Minutes, hms.Seconds); extracted != expected {
+				t.Errorf("ExtractTimeMatches extracted time: %s from string: '%s' (expected: %s)", extracted, str, expected)
+			}
+			if m := matches[0]; str[m.Start:m.End] != m.Text {
+				t.Errorf("ExtractTimeMatches extracted match with wrong offsets: %+v", m)
+			}
+		} else {
+			t.Errorf("ExtractTimeMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package lkdp
+
+import (
+	"log"
+	"time"
+)
+
+// Clock is an interface for providing the reference time
+//
+// '내일', '3일 후', '5분 뒤' 등 상대적인 표현의 기준이 되는 시간
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is a function which implements Clock interface
+type ClockFunc func() time.Time
+
+// Now returns the reference time
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// system clock (= wall clock)
+type systemClock struct{}
+
+// Now returns the current time
+func (c systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Logger is an interface for printing debug messages
+//
+// (*log.Logger satisfies this interface)
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// logger which prints only when `Verbose` is true
+type verboseLogger struct{}
+
+// Printf prints debug messages with the standard logger
+func (l verboseLogger) Printf(format string, v ...interface{}) {
+	if Verbose {
+		log.Printf(format, v...)
+	}
+}
+
+// Option is a function for configuring a parser
+type Option func(*Parser)
+
+// WithClock sets the clock which provides the reference time
+//
+// 상대적인 표현의 기준 시간을 제공할 Clock 설정
+func WithClock(clock Clock) Option {
+	return func(p *Parser) {
+		if clock != nil {
+			p.clock = clock
+		}
+	}
+}
+
+// WithReferenceTime sets the fixed reference time
+//
+// 상대적인 표현의 기준 시간을 고정된 시간으로 설정 (eg: 메시지 작성 시간)
+func WithReferenceTime(t time.Time) Option {
+	return WithClock(ClockFunc(func() time.Time {
+		return t
+	}))
+}
+
+// WithLocation sets the location (timezone) of extracted dates and times
+//
+// 지역 설정 (timezone)
+func WithLocation(location *time.Location) Option {
+	return func(p *Parser) {
+		if location != nil {
+			p.location = location
+		}
+	}
+}
+
+// WithLogger sets the logger for debug messages
+//
+// `nil` logger disables debug messages
+func WithLogger(logger Logger) Option {
+	return func(p *Parser) {
+		p.logger = logger
+	}
+}
+
+// WithRuleSet sets the rule set used for extraction
+//
+// eg: WithRuleSet(DefaultRuleSet().Without(RuleDateExact2))
+func WithRuleSet(rules RuleSet) Option {
+	return func(p *Parser) {
+		p.rules = rules
+	}
+}
+
+// WithWeekStart sets the first day of a week (eg: time.Monday, time.Sunday)
+//
+// '이번 주 일요일', '다음 주 월요일' 등을 계산할 때 기준이 되는 한 주의 시작 요일
+func WithWeekStart(weekday time.Weekday) Option {
+	return func(p *Parser) {
+		p.weekStart = weekday
+	}
+}
+
+// WithDayPeriods sets the periods of a day (eg: '아침', '저녁')
+//
+// 숫자 시간의 오전/오후 구분('저녁 7시' => 19시)과 단독으로 쓰인 경우의 시간('점심' => 12시)에 사용,
+// 빈 목록은 이 기능을 끔
+//
+// eg: WithDayPeriods(append(DefaultDayPeriods(), DayPeriod{Name: "퇴근길", From: 17, To: 21, Default: Hms{Hours: 18}}))
+func WithDayPeriods(periods []DayPeriod) Option {
+	return func(p *Parser) {
+		p.dayPeriods = newDayPeriods(periods)
+	}
+}
+
+// WithAmbiguityPolicy sets the policy for ambiguous times without AM/PM (eg: PreferFuture, PreferBusinessHours)
+//
+// 오전/오후가 없는 시간('3시')의 해석 방법, `nil`이면 모호한 그대로(`Hms.Ambiguous` = true) 두고,
+// RejectAmbiguous이면 ErrAmbiguous 에러를 반환
+func WithAmbiguityPolicy(policy AmbiguityPolicy) Option {
+	return func(p *Parser) {
+		p.ambiguity = policy
+	}
+}
+
+// WithStrict sets whether to reject invalid dates/times with errors or not
+//
+// strict 모드에서는 존재하지 않는 날짜('2월 30일')나 범위를 벗어난 시간('25시 70분')이 있으면
+// ErrInvalidDate, ErrOutOfRange 종류의 `*Error`를 반환하고,
+// 그렇지 않으면(기본) 정규화한 값('3월 2일', 다음 날 02:10)을 `Normalized` = true로 표시해서 반환
+func WithStrict(strict bool) Option {
+	return func(p *Parser) {
+		p.strict = strict
+	}
+}
+
+// WithMessages sets the localized messages of errors (eg: EnglishMessages)
+//
+// 에러 메시지 설정 (기본: KoreanMessages)
+func WithMessages(messages Messages) Option {
+	return func(p *Parser) {
+		p.messages = messages
+	}
+}
+
+// WithCenturyWindow sets the number of years after the reference year, for resolving two-digit years
+//
+// 두 자리 연도('21년', '21.3.1')는 (기준 연도 + years)로 끝나는 100년 중의 연도로 계산 (기본: DefaultCenturyWindow)
+//
+// eg: 2021년 기준, WithCenturyWindow(20)이면 '41년' => 2041년, '42년' => 1942년
+func WithCenturyWindow(years int) Option {
+	return func(p *Parser) {
+		p.centuryWindow = years
+	}
+}
+
+// WithResolution sets how to resolve incomplete dates and times (eg: ResolveNextOccurrence)
+//
+// ResolveNextOccurrence이면 연도(와 월)가 없는 날짜('1월 3일', '15일')는 (`ifEmptyFillAsToday`가 true인 경우)
+// 가장 가까운 미래의 날짜로, 날짜가 없는 시간('3시')은 가장 가까운 미래의 시간으로 계산하고,
+// (WithTenseDetector로 시제를 추론하는 경우) 과거 시제의 문장('만났다', '했었어')에서는 가장 가까운 과거로 계산
+func WithResolution(resolution Resolution) Option {
+	return func(p *Parser) {
+		p.resolution = resolution
+	}
+}
+
+// WithTenseDetector sets the detector of tenses around expressions (eg: DefaultTenseDetector)
+//
+// 추론한 시제(`Tense`)는 각 결과에 포함되고, ResolveNextOccurrence와 PreferFuture 정책에서
+// 과거 시제의 문장('3시에 만났다')을 가장 가까운 과거로 계산하는 데 사용 (기본: `nil`, 시제를 추론하지 않음)
+func WithTenseDetector(detector TenseDetector) Option {
+	return func(p *Parser) {
+		p.tenseDetector = detector
+	}
+}
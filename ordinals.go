@@ -0,0 +1,141 @@
+package lkdp
+
+// 몇째 주 요일, 마지막 날
+//
+// eg: '이번 달 셋째 주 금요일', '다음 달 마지막 금요일', '이달의 마지막 날', '3월 첫째 주 월요일'
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ordinal numbers (eg: '둘째', '두 번째')
+var ordinalNumbers = []struct {
+	word string
+	n    int
+}{
+	{`첫`, 1},
+	{`둘`, 2},
+	{`두`, 2},
+	{`셋`, 3},
+	{`세`, 3},
+	{`넷`, 4},
+	{`네`, 4},
+	{`다섯`, 5},
+}
+
+// returns the regular expression of ordinal numbers (without '째')
+func ordinalsExpression() string {
+	words := []string{}
+	for _, o := range ordinalNumbers {
+		words = append(words, o.word)
+	}
+	return strings.Join(append(words, `\d`), "|")
+}
+
+// returns the n-th of given ordinal expressions (negative = from the end, eg: -1 for '마지막')
+//
+// returns false if it is not a valid ordinal number (eg: '0째')
+func ordinalOf(ordinal, first, last string) (int, bool) {
+	switch {
+	case first != "":
+		return 1, true
+	case last != "":
+		return -1, true
+	}
+
+	for _, o := range ordinalNumbers {
+		if o.word == ordinal {
+			return o.n, true
+		}
+	}
+	n, err := strconv.Atoi(ordinal)
+	return n, err == nil && n > 0
+}
+
+// returns the n-th weekday of the month which starts from given date (negative = from the end of the month)
+//
+// returns false if the month does not have it (eg: the 5th friday)
+func nthWeekday(first time.Time, n int, weekday time.Weekday) (time.Time, bool) {
+	var date time.Time
+	if n > 0 {
+		date = first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+(n-1)*7)
+	} else {
+		last := first.AddDate(0, 1, -1)
+		date = last.AddDate(0, 0, -(int(last.Weekday())-int(weekday)+7)%7+(n+1)*7)
+	}
+	return date, date.Month() == first.Month()
+}
+
+// '이번 달 셋째 주 금요일', '다음 달 마지막 금요일', '이달의 마지막 날', '2021년 3월 첫 번째 월요일' 등
+//
+// 월 없이 주어진 경우 이번 달로 계산 ('마지막 날'은 월과 함께 주어진 경우만),
+// 연도 없이 월만 주어진 경우, ifEmptyFillAsToday가 true이면 올해로 계산
+func parseDateOrdinal1(e *extraction, slices []string) (DateMatch, bool) {
+	yearStr, monthStr, relative, relative2 := slices[1], slices[2], slices[3], slices[4]
+	ordinal, first, last, weekdayStr, lastDay := slices[5], slices[6], slices[7], slices[8], slices[10] != ""
+
+	var month time.Time
+	var missing dateParts
+	switch {
+	case monthStr != "": // '3월 첫째 주 월요일', '2021년 3월 마지막 날'
+		month64, _ := strconv.ParseInt(monthStr, 10, 16)
+		if month64 < 1 || month64 > 12 {
+			return DateMatch{invalid: newError(ErrInvalidDate, "%d월은 존재하지 않습니다", month64)}, true
+		}
+
+		year := 0
+		if yearStr != "" {
+			var ok bool
+			if year, ok = e.year(yearStr); !ok {
+				return DateMatch{invalid: newError(ErrOutOfRange, "%s년은 범위를 벗어났습니다", yearStr)}, true
+			}
+		} else {
+			missing = dateYear
+			if e.fill {
+				year = e.now.Year()
+			}
+		}
+		month = time.Date(year, time.Month(month64), 1, 0, 0, 0, 0, e.location)
+	case relative != "" || relative2 != "": // '다음 달', '이달', ...
+		month = time.Date(e.now.Year(), e.now.Month()+time.Month(relativeMonths(relative, relative2)), 1, 0, 0, 0, 0, e.location)
+	default: // this month (not for '마지막 날')
+		if lastDay {
+			return DateMatch{}, false
+		}
+		month = time.Date(e.now.Year(), e.now.Month(), 1, 0, 0, 0, 0, e.location)
+	}
+
+	var n int
+	var weekday time.Weekday
+	if !lastDay {
+		var ok bool
+		if n, ok = ordinalOf(ordinal, first, last); !ok {
+			return DateMatch{}, false
+		}
+		weekday, _ = weekdayOf(weekdayStr)
+	}
+
+	// the date in the month which starts from given date
+	dateIn := func(first time.Time) (time.Time, bool) {
+		if lastDay {
+			return first.AddDate(0, 1, -1), true
+		}
+		return nthWeekday(first, n, weekday)
+	}
+
+	date, ok := dateIn(month)
+	if !ok {
+		return DateMatch{invalid: newError(ErrInvalidDate, "%d년 %d월에는 %d번째 %s%s이 없습니다", month.Year(), month.Month(), n, weekdayStr, slices[9])}, true
+	}
+
+	m := DateMatch{Date: date, missing: missing}
+	if missing&dateYear != 0 { // (not the same day in other years, eg: '3월 첫째 주 월요일')
+		m.ofYear = func(year int) (time.Time, bool) {
+			return dateIn(time.Date(year, month.Month(), 1, 0, 0, 0, 0, e.location))
+		}
+	}
+
+	return m, true
+}
@@ -0,0 +1,88 @@
+package lkdp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOrdinalDays(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 10, 9, 0, 0, 0, loc) // Wednesday
+
+	for str, expected := range map[string]struct {
+		text string
+		date string
+	}{
+		`이번 달 셋째 주 금요일에 보자`: {`이번 달 셋째 주 금요일`, `2021-03-19`},
+		`다음 달 마지막 금요일`:      {`다음 달 마지막 금요일`, `2021-04-30`},
+		`이달의 마지막 날`:         {`이달의 마지막 날`, `2021-03-31`},
+		`다음 달 마지막 날까지`:      {`다음 달 마지막 날`, `2021-04-30`},
+		`지난달 마지막 날`:         {`지난달 마지막 날`, `2021-02-28`},
+		`익월 첫째 주 월요일`:       {`익월 첫째 주 월요일`, `2021-04-05`},
+		`3월 첫 월요일`:          {`3월 첫 월요일`, `2021-03-01`},
+		`2021년 5월 두 번째 일요일`: {`2021년 5월 두 번째 일요일`, `2021-05-09`},
+		`둘째 주 화요일`:          {`둘째 주 화요일`, `2021-03-09`},
+		`마지막 주 금요일`:         {`마지막 주 금요일`, `2021-03-26`},
+	} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+			if m := matches[0]; m.Text != expected.text || m.Date.Format("2006-01-02") != expected.date {
+				t.Errorf("ExtractDateMatches extracted: '%s' %s from string: '%s' (expected: %+v)", m.Text, m.Date.Format("2006-01-02"), str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// without years: resolved and inherited like other dates
+	if date, err := ExtractDate(`3월 첫 월요일에 보자`, true, WithReferenceTime(ref), WithResolution(ResolveNextOccurrence)); err != nil || date.Format("2006-01-02") != `2022-03-07` {
+		t.Errorf("ExtractDate extracted: %s (error: %v)", date.Format("2006-01-02"), err)
+	}
+	if matches, err := ExtractDateMatches(`2020년 1월 3일, 2월 마지막 날`, true, WithReferenceTime(ref)); err != nil || len(matches) != 2 || matches[1].Date.Format("2006-01-02") != `2020-02-29` {
+		t.Errorf("ExtractDateMatches extracted: %+v (error: %v)", matches, err)
+	}
+	if date, err := ExtractDate(`3월 첫 월요일`, false, WithReferenceTime(ref)); err != nil || date.Year() != 0 {
+		t.Errorf("ExtractDate extracted: %s (error: %v)", date.Format("2006-01-02"), err)
+	}
+
+	// '마지막 날' without a month
+	if dates, err := ExtractDates(`방학 마지막 날`, true, WithReferenceTime(ref)); err == nil {
+		t.Errorf("ExtractDates should fail with string: '방학 마지막 날' (extracted: %v)", dates)
+	}
+
+	// no 5th friday in march 2021
+	if _, err := ExtractDate(`이번 달 다섯째 주 금요일`, true, WithReferenceTime(ref), WithStrict(true)); !errors.Is(err, ErrInvalidDate) {
+		t.Errorf("ExtractDate should fail with ErrInvalidDate (error: %v)", err)
+	}
+}
+
+func TestOrdinalRecurrences(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 9, 0, 0, 0, loc) // Monday
+
+	for str, expected := range map[string]struct {
+		rrule       string
+		occurrences []string
+	}{
+		`매월 둘째 주 화요일`:      {`FREQ=MONTHLY;BYDAY=2TU`, []string{`2021-03-09 00:00`, `2021-04-13 00:00`}},
+		`매달 마지막 금요일 오후 3시`: {`FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=15;BYMINUTE=0;BYSECOND=0`, []string{`2021-03-26 15:00`, `2021-04-30 15:00`}},
+		`매월 첫 번째 월요일`:      {`FREQ=MONTHLY;BYDAY=1MO`, []string{`2021-04-05 00:00`, `2021-05-03 00:00`}},
+		`매월 마지막 날`:         {`FREQ=MONTHLY;BYMONTHDAY=-1`, []string{`2021-03-31 00:00`, `2021-04-30 00:00`}},
+		`2개월마다 셋째 주 수요일`:   {`FREQ=MONTHLY;INTERVAL=2;BYDAY=3WE`, []string{`2021-03-17 00:00`, `2021-05-19 00:00`}},
+	} {
+		if r, err := ExtractRecurrence(str, WithReferenceTime(ref)); err == nil {
+			if r.RRule() != expected.rrule {
+				t.Errorf("ExtractRecurrence extracted: %s from string: '%s' (expected: %s)", r.RRule(), str, expected.rrule)
+			}
+			occurrences := r.Occurrences(ref, len(expected.occurrences))
+			for i, o := range occurrences {
+				if o.Format("2006-01-02 15:04") != expected.occurrences[i] {
+					t.Errorf("Occurrences returned: %v for string: '%s' (expected: %v)", occurrences, str, expected.occurrences)
+					break
+				}
+			}
+		} else {
+			t.Errorf("ExtractRecurrence failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+}
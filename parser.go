@@ -0,0 +1,379 @@
+package lkdp
+
+import (
+	"strings"
+	"time"
+)
+
+// Parser is a date/time parser with its own location, reference clock, logger and rule set
+//
+// 각 Parser는 독립적으로 설정되므로, 여러 goroutine에서 서로 다른 설정으로 동시에 사용 가능
+type Parser struct {
+	location *time.Location
+	clock    Clock
+	logger   Logger
+	rules    RuleSet
+
+	weekStart  time.Weekday    // the first day of a week
+	dayPeriods *dayPeriods     // periods of a day (eg: '아침', '저녁')
+	ambiguity  AmbiguityPolicy // policy for ambiguous times (nil = keep them ambiguous)
+	strict     bool            // whether to reject invalid dates/times or not
+	messages   Messages        // localized messages of errors (nil = KoreanMessages)
+
+	centuryWindow int           // number of years after the reference year, for resolving two-digit years
+	resolution    Resolution    // how to resolve incomplete dates and times
+	tenseDetector TenseDetector // detector of tenses around expressions (nil = not detected)
+}
+
+// NewParser returns a new parser configured with given options
+//
+// (default: location = DefaultLocation, clock = wall clock, logger = nil, rules = DefaultRuleSet(), week start = Monday,
+// day periods = DefaultDayPeriods(), ambiguity policy = nil, strict = false, messages = KoreanMessages,
+// century window = DefaultCenturyWindow, resolution = ResolveReferenceDate, tense detector = nil)
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{
+		location:   _location,
+		clock:      systemClock{},
+		rules:      DefaultRuleSet(),
+		weekStart:  time.Monday,
+		dayPeriods: defaultDayPeriods,
+
+		centuryWindow: DefaultCenturyWindow,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// returns a copy of this parser with given options applied
+func (p *Parser) with(opts ...Option) *Parser {
+	if len(opts) <= 0 {
+		return p
+	}
+
+	copied := *p
+	for _, opt := range opts {
+		opt(&copied)
+	}
+	return &copied
+}
+
+// Location returns the location of this parser
+func (p *Parser) Location() *time.Location {
+	return p.location
+}
+
+// Now returns the reference time of this parser (in its location)
+func (p *Parser) Now() time.Time {
+	return p.clock.Now().In(p.location)
+}
+
+// per-extraction context
+type extraction struct {
+	*Parser
+
+	str  string    // given string
+	now  time.Time // reference time
+	fill bool      // whether to fill empty values with the reference time or not
+
+	normalized string // given string with numerals normalized
+	offsets    []int  // byte offsets of the normalized string in the given string
+
+	rejected []*Error // invalid dates/times rejected in strict mode
+}
+
+// returns a new extraction context for given string
+func (p *Parser) newExtraction(str string, fill bool) *extraction {
+	normalized, offsets := normalizeNumerals(str)
+
+	return &extraction{
+		Parser:     p,
+		str:        str,
+		now:        p.Now(),
+		fill:       fill,
+		normalized: normalized,
+		offsets:    offsets,
+	}
+}
+
+// returns the span in the given string, of normalized string's normalized[start:end]
+func (e *extraction) span(start, end int) Span {
+	return newSpan(e.str, e.offsets[start], e.offsets[end])
+}
+
+// ExtractDateMatches extracts all dates with their positions from given string
+//
+// returned matches are ordered by their positions
+//
+// returns `nil` matches on error
+func (p *Parser) ExtractDateMatches(str string, ifEmptyFillAsToday bool) (matches []DateMatch, err error) {
+	e := p.newExtraction(str, ifEmptyFillAsToday)
+	matches = e.dates()
+
+	// invalid dates/times in strict mode
+	if err = e.rejection(); err != nil {
+		return nil, err
+	}
+
+	if len(matches) <= 0 {
+		return nil, e.noMatch(SubjectDate)
+	}
+
+	return matches, nil
+}
+
+// ExtractDates extracts all dates from given string
+//
+// (when the same text is matched multiple times, the first one is used)
+//
+// returns `nil` dates on error
+func (p *Parser) ExtractDates(str string, ifEmptyFillAsToday bool) (dates map[string]time.Time, err error) {
+	var matches []DateMatch
+	if matches, err = p.ExtractDateMatches(str, ifEmptyFillAsToday); err != nil {
+		return nil, err
+	}
+
+	dates = map[string]time.Time{}
+	for _, m := range matches {
+		if _, exists := dates[m.Text]; !exists {
+			dates[m.Text] = m.Date
+		}
+	}
+
+	return dates, nil
+}
+
+// extract all dates with date rules
+//
+// (dates in relative times are not extracted, eg: '3일' of '3일 10시간 후')
+func (e *extraction) dates() (matches []DateMatch) {
+	all, candidates := e.dateCandidates(0)
+
+	relatives := e.relativeTimeSpans()
+	filtered := candidates[:0]
+	for _, c := range candidates {
+		if !c.within(relatives) {
+			filtered = append(filtered, c)
+		}
+	}
+
+	var prev *DateMatch
+	for _, c := range resolveOverlaps(filtered) {
+		m := all[c.index]
+		e.inheritDate(prev, &m)
+		matches = append(matches, m)
+		prev = &matches[len(matches)-1]
+	}
+
+	return matches
+}
+
+// extract all candidates of dates with date rules
+//
+// priorities of candidates start from given `priority`
+func (e *extraction) dateCandidates(priority int) (all []DateMatch, candidates []candidate) {
+	for i, r := range e.rules.dates {
+		for _, indices := range r.re.FindAllStringSubmatchIndex(e.normalized, -1) {
+			span, slices := e.span(indices[2*r.group], indices[2*r.group+1]), submatches(e.normalized, indices)
+
+			e.debugPrint("%s: matched string = '%s', slices = [%s]", r.name, span.Text, strings.Join(slices, ", "))
+
+			if match, ok := r.parse(e, slices); ok {
+				match.Span, match.Rule, match.Tense = span, r.name, e.tense(span)
+
+				// (invalid dates without years can be valid in other years, eg: '2월 29일')
+				if e.fill && e.resolution == ResolveNextOccurrence {
+					match = e.nearestDate(match, match.Tense == TensePast)
+				}
+
+				if match.invalid != nil {
+					// (invalid lunar dates are always rejected, not to be read as solar dates:
+					// eg: '2021년 4월 8일' of '음력 2021년 윤4월 8일')
+					if e.strict || r.name == RuleDateLunar1 {
+						e.reject(SubjectDate, span, match.invalid)
+						continue
+					}
+					if match.Date.IsZero() { // could not be normalized
+						continue
+					}
+					match.Normalized = true
+				}
+
+				e.debugPrint("%s: extracted ymd = %04d-%02d-%02d", r.name, match.Date.Year(), match.Date.Month(), match.Date.Day())
+
+				candidates = append(candidates, candidate{Span: span, priority: priority + i, index: len(all)})
+				all = append(all, match)
+			}
+		}
+	}
+
+	return all, candidates
+}
+
+// returns the spans of relative times with time units (eg: '3일 10시간 후')
+func (e *extraction) relativeTimeSpans() (spans []Span) {
+	for _, r := range e.rules.times {
+		if r.name != RuleTimeRel1 || r.re == nil {
+			continue
+		}
+		for _, indices := range r.re.FindAllStringSubmatchIndex(e.normalized, -1) {
+			if _, ok := r.parse(e, submatches(e.normalized, indices)); ok {
+				spans = append(spans, e.span(indices[2*r.group], indices[2*r.group+1]))
+			}
+		}
+	}
+	return spans
+}
+
+// ExtractDate extracts date from given string
+//
+// 주어진 한글 string으로부터 패턴에 가장 먼저 맞는 날짜값 추출
+func (p *Parser) ExtractDate(str string, ifEmptyFillAsToday bool) (date time.Time, err error) {
+	var matches []DateMatch
+	if matches, err = p.ExtractDateMatches(str, ifEmptyFillAsToday); err != nil {
+		return time.Time{}, err
+	}
+
+	// the left-most(with the least index) matched date
+	return matches[0].Date, nil
+}
+
+// ExtractTimeMatches extracts all times with their positions from given string
+//
+// returned matches are ordered by their positions
+//
+// returns `nil` matches on error
+func (p *Parser) ExtractTimeMatches(str string, ifEmptyFillAsNow bool) (matches []TimeMatch, err error) {
+	e := p.newExtraction(str, ifEmptyFillAsNow)
+	matches = e.times()
+
+	// invalid dates/times in strict mode
+	if err = e.rejection(); err != nil {
+		return nil, err
+	}
+
+	if len(matches) <= 0 {
+		return nil, e.noMatch(SubjectTime)
+	}
+
+	return matches, nil
+}
+
+// ExtractTimes extracts all times from given string
+//
+// (when the same text is matched multiple times, the first one is used)
+//
+// returns `nil` times on error
+//
+// 주어진 한글 string으로부터 시간 추출
+func (p *Parser) ExtractTimes(str string, ifEmptyFillAsNow bool) (hmss map[string]Hms, err error) {
+	var matches []TimeMatch
+	if matches, err = p.ExtractTimeMatches(str, ifEmptyFillAsNow); err != nil {
+		return nil, err
+	}
+
+	hmss = map[string]Hms{}
+	for _, m := range matches {
+		if _, exists := hmss[m.Text]; !exists {
+			hmss[m.Text] = m.Hms
+		}
+	}
+
+	return hmss, nil
+}
+
+// extract all times with time rules
+//
+// ambiguous times are resolved on the dates right before them (eg: '내일 3시'), or on the reference date
+func (e *extraction) times() (matches []TimeMatch) {
+	all, candidates := e.timeCandidates(0)
+
+	// (dates are only for resolving times here, so invalid ones are not rejected)
+	rejected := e.rejected
+	dates := e.dates()
+	e.rejected = rejected
+
+	var prev *TimeMatch
+	for _, c := range resolveOverlaps(candidates) {
+		m := all[c.index]
+		e.inheritTime(prev, &m)
+		if date, paired := e.pairedDate(dates, &m); paired {
+			e.disambiguate(&m, onDate(date))
+		} else {
+			e.disambiguate(&m, onDate(e.now))
+		}
+		matches = append(matches, m)
+		prev = &matches[len(matches)-1]
+	}
+
+	return matches
+}
+
+// extract all candidates of times with time rules
+//
+// priorities of candidates start from given `priority`
+func (e *extraction) timeCandidates(priority int) (all []TimeMatch, candidates []candidate) {
+	for i, r := range e.rules.times {
+		re := r.re
+		if re == nil && r.reOf != nil {
+			re = r.reOf(e.Parser)
+		}
+		if re == nil {
+			continue
+		}
+
+		for _, indices := range re.FindAllStringSubmatchIndex(e.normalized, -1) {
+			span, slices := e.span(indices[2*r.group], indices[2*r.group+1]), submatches(e.normalized, indices)
+
+			e.debugPrint("%s: matched string = '%s', slices = [%s]", r.name, span.Text, strings.Join(slices, ", "))
+
+			if hms, ok := r.parse(e, slices); ok {
+				// with the period of a day right before it (eg: '저녁 7시'), not for relative times
+				if r.re != nil && r.name != RuleTimeRel1 {
+					if period, start, exists := e.dayPeriodBefore(indices[0]); exists {
+						if applied, ok := period.apply(hms); ok {
+							hms, span = applied, e.span(start, indices[1])
+						}
+					}
+				}
+
+				normalized := false
+				if invalid := hms.invalid(); invalid != nil {
+					if e.strict {
+						e.reject(SubjectTime, span, invalid)
+						continue
+					}
+					hms, normalized = hms.normalized(), true
+				}
+
+				e.debugPrint("%s: extracted hms = %02d:%02d:%02d", r.name, hms.Hours, hms.Minutes, hms.Seconds)
+
+				candidates = append(candidates, candidate{Span: span, priority: priority + i, index: len(all)})
+				all = append(all, TimeMatch{Span: span, Rule: r.name, Hms: hms, Normalized: normalized, Tense: e.tense(span)})
+			}
+		}
+	}
+
+	return all, candidates
+}
+
+// ExtractTime extracts time from given string
+//
+// 주어진 한글 string으로부터 패턴에 가장 '먼저' 맞는 시간값 추출
+func (p *Parser) ExtractTime(str string, ifEmptyFillAsNow bool) (hms Hms, err error) {
+	var matches []TimeMatch
+	if matches, err = p.ExtractTimeMatches(str, ifEmptyFillAsNow); err != nil {
+		return Hms{}, err
+	}
+
+	// the left-most(with the least index) matched time
+	return matches[0].Hms, nil
+}
+
+// print debug messages
+func (p *Parser) debugPrint(format string, v ...interface{}) {
+	if p.logger != nil {
+		p.logger.Printf(format, v...)
+	}
+}
@@ -0,0 +1,134 @@
+package lkdp
+
+import (
+	"bytes"
+	"log"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParser(t *testing.T) {
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	// same instant, but different dates in each location
+	ref := time.Date(2020, 11, 10, 12, 0, 0, 0, time.UTC) // 2020-11-10 21:00 KST, 2020-11-10 07:00 EST
+	late := ref.Add(4 * time.Hour)                        // 2020-11-11 01:00 KST, 2020-11-10 11:00 EST
+
+	parsers := map[string]*Parser{
+		`2020-11-12`: NewParser(WithLocation(seoul), WithReferenceTime(late)),
+		`2020-11-11`: NewParser(WithLocation(newYork), WithReferenceTime(late)),
+	}
+
+	var wg sync.WaitGroup
+	for expected, p := range parsers {
+		wg.Add(1)
+		go func(expected string, p *Parser) {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				if d, err := p.ExtractDate(`내일 보자`, true); err == nil {
+					if d.Format("2006-01-02") != expected || d.Location() != p.Location() {
+						t.Errorf("Parser extracted date: %s (expected: %s)", d, expected)
+						return
+					}
+				} else {
+					t.Errorf("Parser failed to extract date (error: %s)", err)
+					return
+				}
+			}
+		}(expected, p)
+	}
+	wg.Wait()
+
+	// times
+	p := NewParser(WithLocation(newYork), WithReferenceTime(ref))
+	if hms, err := p.ExtractTime(`30분 후`, true); err != nil || hms.Hours != 7 || hms.Minutes != 30 {
+		t.Errorf("Parser extracted time: %02d:%02d (error: %v)", hms.Hours, hms.Minutes, err)
+	}
+}
+
+func TestParserRuleSet(t *testing.T) {
+	rules := DefaultRuleSet()
+	if len(rules.Without(RuleDateExact2).Names()) != len(rules.Names())-1 {
+		t.Errorf("failed to exclude rule: %s from %v", RuleDateExact2, rules.Names())
+	}
+
+	p := NewParser(WithRuleSet(rules.Without(RuleDateExact2)))
+	if _, err := p.ExtractDate(`해방된 날은 1945-08-15`, false); err == nil {
+		t.Errorf("Parser should not extract date without rule: %s", RuleDateExact2)
+	}
+	if _, err := p.ExtractDate(`1945년 8월 15일`, false); err != nil {
+		t.Errorf("Parser failed to extract date (error: %s)", err)
+	}
+
+	// recurrences
+	p = NewParser(WithRuleSet(rules.Without(RuleRecurrenceDaily1)))
+	if _, err := p.ExtractRecurrence(`매일 아침 9시`); err == nil {
+		t.Errorf("Parser should not extract recurrence without rule: %s", RuleRecurrenceDaily1)
+	}
+	if _, err := p.ExtractRecurrence(`매주 월요일`); err != nil {
+		t.Errorf("Parser failed to extract recurrence (error: %s)", err)
+	}
+}
+
+func TestParserLogger(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewParser(WithLogger(log.New(&buf, "", 0)))
+	if _, err := p.ExtractTime(`오후 3시`, false); err != nil {
+		t.Errorf("Parser failed to extract time (error: %s)", err)
+	}
+	if buf.Len() <= 0 {
+		t.Errorf("Parser did not print any debug message")
+	}
+
+	// default parser without `Verbose`
+	buf.Reset()
+	if _, err := NewParser().ExtractTime(`오후 3시`, false); err != nil || buf.Len() > 0 {
+		t.Errorf("Parser without logger should not print debug messages (error: %v)", err)
+	}
+}
+
+// (run with `go test -race`)
+func TestDefaultParserConcurrency(t *testing.T) {
+	defer SetLocation(DefaultLocation)
+
+	ref := time.Date(2020, 11, 10, 12, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 1000; i++ {
+			location := DefaultLocation
+			if i%2 == 0 {
+				location = "America/New_York"
+			}
+			if err := SetLocation(location); err != nil {
+				t.Errorf("SetLocation failed (error: %s)", err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 1000; i++ {
+			if _, err := ExtractDates(`내일 보자`, true, WithReferenceTime(ref)); err != nil {
+				t.Errorf("ExtractDates failed (error: %s)", err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	// location of the default parser
+	if err := SetLocation("America/New_York"); err != nil {
+		t.Fatalf("SetLocation failed (error: %s)", err)
+	}
+	if d, err := ExtractDate(`내일 보자`, true, WithReferenceTime(ref)); err != nil || d.Location().String() != "America/New_York" {
+		t.Errorf("ExtractDate extracted date: %s (error: %v)", d, err)
+	}
+}
@@ -0,0 +1,152 @@
+package lkdp
+
+// 일부만 주어진 날짜 표현
+//
+// eg: '3월에 출시', '2021년 상반기', '2020년 12월', '2022년', '3분기'
+//
+// 특정 일자 대신 해당 기간의 첫날(`Date`)과 마지막 날(`Until`), 그리고 단위(`Granularity`)로 추출
+
+import (
+	"strconv"
+	"time"
+)
+
+// Granularity is the unit of a date (eg: month for '3월', year for '2021년')
+type Granularity int
+
+// granularities of dates
+const (
+	GranularityDay Granularity = iota
+	GranularityWeek
+	GranularityMonth
+	GranularityQuarter
+	GranularityHalf
+	GranularityYear
+)
+
+// String returns the name of this granularity
+func (g Granularity) String() string {
+	switch g {
+	case GranularityDay:
+		return "day"
+	case GranularityWeek:
+		return "week"
+	case GranularityMonth:
+		return "month"
+	case GranularityQuarter:
+		return "quarter"
+	case GranularityHalf:
+		return "half"
+	case GranularityYear:
+		return "year"
+	}
+	return "unknown"
+}
+
+// returns the first and the last date of the period of this granularity, which includes given date
+//
+// (weeks start from given `weekStart`)
+func (g Granularity) period(date time.Time, weekStart time.Weekday) (from, to time.Time) {
+	year, month := date.Year(), date.Month()
+
+	switch g {
+	case GranularityWeek:
+		from = startOfWeek(time.Date(year, month, date.Day(), 0, 0, 0, 0, date.Location()), weekStart)
+		return from, from.AddDate(0, 0, 6)
+	case GranularityMonth:
+		from = time.Date(year, month, 1, 0, 0, 0, 0, date.Location())
+		return from, from.AddDate(0, 1, -1)
+	case GranularityQuarter:
+		from = time.Date(year, (month-1)/3*3+1, 1, 0, 0, 0, 0, date.Location())
+		return from, from.AddDate(0, 3, -1)
+	case GranularityHalf:
+		from = time.Date(year, (month-1)/6*6+1, 1, 0, 0, 0, 0, date.Location())
+		return from, from.AddDate(0, 6, -1)
+	case GranularityYear:
+		from = time.Date(year, 1, 1, 0, 0, 0, 0, date.Location())
+		return from, from.AddDate(1, 0, -1)
+	}
+	from = time.Date(year, month, date.Day(), 0, 0, 0, 0, date.Location())
+	return from, from
+}
+
+// returns given match moved to given date, keeping its period
+func (m DateMatch) movedTo(date time.Time) DateMatch {
+	if !m.Until.IsZero() {
+		if m.Granularity == GranularityDay || m.Granularity == GranularityWeek { // (eg: '추석 연휴', '이번 주')
+			m.Until = date.AddDate(0, 0, daysBetween(m.Date, m.Until))
+		} else {
+			_, m.Until = m.Granularity.period(date, m.Date.Weekday()) // (the first date of a period starts its week)
+		}
+	}
+	m.Date = date
+	return m
+}
+
+// returns given match moved to given year (eg: 2022년 3월 7일 for '3월 첫째 주 월요일'),
+// or false if it does not exist in the year (eg: '2월 29일' in 2021)
+func (m DateMatch) inYear(year int) (DateMatch, bool) {
+	date := time.Date(year, m.Date.Month(), m.Date.Day(), 0, 0, 0, 0, m.Date.Location())
+	if m.ofYear != nil {
+		var ok bool
+		if date, ok = m.ofYear(year); !ok {
+			return m, false
+		}
+	} else if date.Day() != m.Date.Day() {
+		return m, false
+	}
+	return m.movedTo(date), true
+}
+
+// '3월', '2021년 3월', '2021년', '2021년 상반기', '하반기', '21년 3분기' 등
+//
+// 연도 없이 월/반기/분기만 주어진 경우, ifEmptyFillAsToday가 true이면 올해로 계산
+func parseDatePartial1(e *extraction, slices []string) (DateMatch, bool) {
+	abbreviated, yearStr := slices[1], slices[2]
+	monthStr, halfStr, quarterStr := slices[3]+slices[6], slices[4]+slices[7], slices[5]+slices[8]
+
+	// year only: 4 digits or abbreviated (eg: '2021년', ''21년', but not '10년 동안')
+	if monthStr == "" && halfStr == "" && quarterStr == "" && abbreviated == "" && len(yearStr) != 4 {
+		return DateMatch{}, false
+	}
+
+	var year int
+	var missing dateParts
+	if yearStr != "" {
+		var ok bool
+		if year, ok = e.year(yearStr); !ok {
+			return DateMatch{invalid: newError(ErrOutOfRange, "%s년은 범위를 벗어났습니다", yearStr)}, true
+		}
+	} else {
+		missing |= dateYear
+		if e.fill {
+			year = e.now.Year()
+		}
+	}
+
+	granularity, month := GranularityYear, 1
+	switch {
+	case monthStr != "":
+		granularity = GranularityMonth
+		month64, _ := strconv.ParseInt(monthStr, 10, 16)
+		if month = int(month64); month < 1 || month > 12 {
+			return DateMatch{invalid: newError(ErrInvalidDate, "%d월은 존재하지 않습니다", month)}, true
+		}
+	case halfStr != "":
+		granularity = GranularityHalf
+		if halfStr == ExpressionSecondHalf1 || halfStr == ExpressionSecondHalf2 {
+			month = 7
+		}
+	case quarterStr != "":
+		granularity = GranularityQuarter
+		quarter, _ := strconv.Atoi(quarterStr)
+		month = (quarter-1)*3 + 1
+	default:
+		missing |= dateMonth
+	}
+	missing |= dateDay
+
+	from, to := granularity.period(time.Date(year, time.Month(month), 1, 0, 0, 0, 0, e.location), e.weekStart)
+
+	return DateMatch{Date: from, Until: to, Granularity: granularity, missing: missing}, true
+}
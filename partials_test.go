@@ -0,0 +1,89 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPartialDates(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 10, 9, 0, 0, 0, loc)
+
+	for str, expected := range map[string]struct {
+		text        string
+		granularity Granularity
+		from, until string
+	}{
+		`3월에 출시`:       {`3월`, GranularityMonth, `2021-03-01`, `2021-03-31`},
+		`2020년 12월`:    {`2020년 12월`, GranularityMonth, `2020-12-01`, `2020-12-31`},
+		`2021년 상반기 실적`: {`2021년 상반기`, GranularityHalf, `2021-01-01`, `2021-06-30`},
+		`하반기`:          {`하반기`, GranularityHalf, `2021-07-01`, `2021-12-31`},
+		`21년 3분기`:      {`21년 3분기`, GranularityQuarter, `2021-07-01`, `2021-09-30`},
+		`2022년 목표`:     {`2022년`, GranularityYear, `2022-01-01`, `2022-12-31`},
+		`'22년`:         {`'22년`, GranularityYear, `2022-01-01`, `2022-12-31`},
+		`2월`:           {`2월`, GranularityMonth, `2021-02-01`, `2021-02-28`},
+		`81년생`:         {`81년생`, GranularityYear, `1981-01-01`, `1981-12-31`},
+		`2021년 3월 5일`:  {`2021년 3월 5일`, GranularityDay, `2021-03-05`, `0001-01-01`},
+	} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+			m := matches[0]
+			if m.Text != expected.text || m.Granularity != expected.granularity || m.Date.Format("2006-01-02") != expected.from || m.Until.Format("2006-01-02") != expected.until {
+				t.Errorf("ExtractDateMatches extracted: '%s' %s %s ~ %s from string: '%s' (expected: %+v)", m.Text, m.Granularity, m.Date.Format("2006-01-02"), m.Until.Format("2006-01-02"), str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// not dates
+	for _, str := range []string{`10년 동안`, `3개월`, `5년`} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+			t.Errorf("ExtractDateMatches should fail with string: '%s' (extracted: %+v)", str, matches)
+		}
+	}
+
+	// without filling the year
+	if matches, err := ExtractDateMatches(`3월`, false, WithReferenceTime(ref)); err != nil || matches[0].Date.Year() != 0 || matches[0].Date.Month() != 3 {
+		t.Errorf("ExtractDateMatches returned: %+v (error: %v)", matches, err)
+	}
+
+	// ranges
+	for str, expected := range map[string][]string{
+		`2021년 3월`:    {`2021-03-01`, `2021-03-31`},
+		`1월부터 3월까지`:   {`2021-01-01`, `2021-03-31`},
+		`12월부터 2월까지`:  {`2021-12-01`, `2022-02-28`},
+		`2021년~2023년`: {`2021-01-01`, `2023-12-31`},
+	} {
+		if r, err := ExtractRange(str, true, WithReferenceTime(ref)); err != nil || r.From.Format("2006-01-02") != expected[0] || r.To.Format("2006-01-02") != expected[1] {
+			t.Errorf("ExtractRange extracted: %s ~ %s from string: '%s' (expected: %v, error: %v)", r.From.Format("2006-01-02"), r.To.Format("2006-01-02"), str, expected, err)
+		}
+	}
+}
+
+func TestGranularityString(t *testing.T) {
+	for g, expected := range map[Granularity]string{
+		GranularityDay:     "day",
+		GranularityWeek:    "week",
+		GranularityMonth:   "month",
+		GranularityQuarter: "quarter",
+		GranularityHalf:    "half",
+		GranularityYear:    "year",
+	} {
+		if g.String() != expected {
+			t.Errorf("Granularity.String returned: %s (expected: %s)", g, expected)
+		}
+	}
+}
+
+func TestGranularityPeriod(t *testing.T) {
+	date := time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC) // Wednesday
+
+	for weekStart, expected := range map[time.Weekday][]string{
+		time.Monday: {`2021-03-08`, `2021-03-14`},
+		time.Sunday: {`2021-03-07`, `2021-03-13`},
+	} {
+		if from, to := GranularityWeek.period(date, weekStart); from.Format("2006-01-02") != expected[0] || to.Format("2006-01-02") != expected[1] {
+			t.Errorf("Granularity.period returned: %s ~ %s for week start: %s (expected: %v)", from.Format("2006-01-02"), to.Format("2006-01-02"), weekStart, expected)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package lkdp
+
+// 기간(범위) 표현
+//
+// eg: '12월 12일부터 6월 2일까지', '5시 01분 ~ 15시 6분', '3월 5일~7일', '3시와 5시 사이'
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// text allowed between the start and the end of a range (eg: '부터', '~', '-', '에서', '와', '부터 다음 해')
+var rangeGapRe = regexp.MustCompile(fmt.Sprintf(`^\s*(부터|에서|와|과|하고)?\s*(~|～|-|–)?\s*(?:%s)?\s*$`, contextNextYear))
+
+// text allowed right after the end of a range (eg: '까지', '사이')
+var rangeEndRe = regexp.MustCompile(`^\s*(까지|사이)`)
+
+// Range is a range of dates/times
+type Range struct {
+	From, To time.Time
+}
+
+// ExtractRangeMatches extracts all ranges of dates/times and their positions from given string
+//
+// ranges are expressed as 'A부터 B까지', 'A ~ B', 'A-B', 'A에서 B까지', or 'A와 B 사이',
+// and missing parts of B are inherited from A (eg: month of '3월 5일~7일', PM of '오후 3시~5시').
+// periods of holidays (eg: '추석 연휴') are also extracted as ranges.
+//
+// returned matches are ordered by their positions
+//
+// returns `nil` matches on error
+func (p *Parser) ExtractRangeMatches(str string, ifEmptyFillAsNow bool) (matches []RangeMatch, err error) {
+	e := p.newExtraction(str, ifEmptyFillAsNow)
+	matches = e.ranges()
+
+	// invalid dates/times in strict mode
+	if err = e.rejection(); err != nil {
+		return nil, err
+	}
+
+	if len(matches) <= 0 {
+		return nil, e.noMatch(SubjectRange)
+	}
+
+	return matches, nil
+}
+
+// ExtractRanges extracts all ranges of dates/times from given string
+//
+// (when the same text is matched multiple times, the first one is used)
+//
+// returns `nil` ranges on error
+//
+// 주어진 한글 string으로부터 기간 추출
+func (p *Parser) ExtractRanges(str string, ifEmptyFillAsNow bool) (ranges map[string]Range, err error) {
+	var matches []RangeMatch
+	if matches, err = p.ExtractRangeMatches(str, ifEmptyFillAsNow); err != nil {
+		return nil, err
+	}
+
+	ranges = map[string]Range{}
+	for _, m := range matches {
+		if _, exists := ranges[m.Text]; !exists {
+			ranges[m.Text] = Range{From: m.From.DateTime, To: m.To.DateTime}
+		}
+	}
+
+	return ranges, nil
+}
+
+// ExtractRange extracts a range of dates/times from given string
+//
+// 주어진 한글 string으로부터 패턴에 가장 먼저 맞는 기간 추출
+func (p *Parser) ExtractRange(str string, ifEmptyFillAsNow bool) (r Range, err error) {
+	var matches []RangeMatch
+	if matches, err = p.ExtractRangeMatches(str, ifEmptyFillAsNow); err != nil {
+		return Range{}, err
+	}
+
+	// the left-most(with the least index) matched range
+	return Range{From: matches[0].From.DateTime, To: matches[0].To.DateTime}, nil
+}
+
+// extract all ranges
+func (e *extraction) ranges() (matches []RangeMatch) {
+	dateTimes := e.dateTimes()
+
+	for i := 0; i < len(dateTimes); i++ {
+		from := dateTimes[i]
+
+		// 'A부터 B까지', 'A ~ B', ...
+		if i+1 < len(dateTimes) {
+			to := dateTimes[i+1]
+			if end, ok := e.rangeEnd(from, to); ok {
+				matches = append(matches, RangeMatch{
+					Span: newSpan(e.str, from.Start, end),
+					From: from,
+					To:   e.inherit(from, to),
+					now:  e.now,
+				})
+				i++
+				continue
+			}
+		}
+
+		// periods of holidays and partial dates (eg: '추석 연휴', '2021년 3월')
+		if from.DateMatch != nil && !from.DateMatch.Until.IsZero() {
+			matches = append(matches, RangeMatch{
+				Span: from.Span,
+				From: from,
+				To: DateTimeMatch{
+					Span:      from.Span,
+					DateMatch: from.DateMatch,
+					DateTime:  from.DateMatch.Until,
+					now:       e.now,
+				},
+				now: e.now,
+			})
+		}
+	}
+
+	return matches
+}
+
+// check if given date/times form a range, and return the end offset of it (including '까지', '사이')
+func (e *extraction) rangeEnd(from, to DateTimeMatch) (end int, ok bool) {
+	gap := rangeGapRe.FindStringSubmatch(e.str[from.End:to.Start])
+	if gap == nil || (gap[1] == "" && gap[2] == "") {
+		return 0, false
+	}
+
+	end, suffix := to.End, ""
+	if indices := rangeEndRe.FindStringSubmatchIndex(e.str[to.End:]); indices != nil {
+		end, suffix = to.End+indices[1], e.str[to.End+indices[2]:to.End+indices[3]]
+	}
+
+	switch gap[1] {
+	case "에서": // 'A에서 B까지', 'A에서 B 사이'
+		return end, suffix != "" || gap[2] != ""
+	case "와", "과", "하고": // 'A와 B 사이'
+		return end, suffix == "사이"
+	}
+	return end, true
+}
+
+// returns the end of a range with its missing parts inherited from the start of it
+//
+// (eg: month of '3월 5일~7일', date of '내일 3시부터 5시까지', PM of '오후 3시~5시' which is inherited with the context)
+func (e *extraction) inherit(from, to DateTimeMatch) DateTimeMatch {
+	var date time.Time
+	switch {
+	case to.DateMatch == nil: // no date: use the date of the start
+		date = time.Date(from.DateTime.Year(), from.DateTime.Month(), from.DateTime.Day(), 0, 0, 0, 0, e.location)
+	case from.DateMatch != nil && to.DateMatch.missing != 0: // partial date: fill missing year/month
+		inherited, _ := e.inheritedDateOf(from.DateMatch, to.DateMatch, true)
+
+		// should not precede the start (eg: '12월 12일부터 6월 2일까지' => 6월 2일 of the next year)
+		if inherited.Date.Before(from.DateMatch.Date) {
+			if to.DateMatch.missing&dateMonth != 0 {
+				inherited = inherited.movedTo(inherited.Date.AddDate(0, 1, 0))
+			} else if next, ok := inherited.inYear(inherited.Date.Year() + 1); ok {
+				inherited = next
+			}
+		}
+		to.DateMatch, date = &inherited, inherited.Date
+	default:
+		date = to.DateMatch.Date
+	}
+
+	if to.TimeMatch == nil {
+		to.DateTime = date
+		if to.DateMatch != nil && !to.DateMatch.Until.IsZero() { // the last date of the period (eg: '1월부터 3월까지')
+			to.DateTime = to.DateMatch.Until
+		}
+		return to
+	}
+
+	to.DateTime = e.combine(date, to.TimeMatch.Hms)
+	if to.Candidates != nil {
+		to.Candidates = e.dateTimeCandidates(to.TimeMatch, onDate(date))
+	}
+
+	// the end without date should not precede the start (eg: '23시~2시' => 2시 of the next day),
+	// and neither should its candidates
+	if to.DateMatch == nil {
+		if to.DateTime.Before(from.DateTime) {
+			to.DateTime = to.DateTime.AddDate(0, 0, 1)
+		}
+		for i, c := range to.Candidates {
+			if c.Before(from.DateTime) {
+				to.Candidates[i] = c.AddDate(0, 0, 1)
+			}
+		}
+	}
+
+	return to
+}
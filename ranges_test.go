@@ -0,0 +1,64 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExtractRanges(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 9, 0, 0, 0, loc)
+
+	for str, expected := range map[string][]string{
+		`12월 12일부터 다음 해 6월 2일까지`: {`2021-12-12 00:00`, `2022-06-02 00:00`},
+		`12월 12일부터 6월 2일까지`:      {`2021-12-12 00:00`, `2022-06-02 00:00`},
+		`5시 01분 ~ 15시 6분`:        {`2021-03-01 05:01`, `2021-03-01 15:06`},
+		`3월 5일~7일`:               {`2021-03-05 00:00`, `2021-03-07 00:00`},
+		`1월 30일부터 2일까지`:          {`2021-01-30 00:00`, `2021-02-02 00:00`},
+		`내일 오후 3시부터 5시까지 회의`:     {`2021-03-02 15:00`, `2021-03-02 17:00`},
+		`오후 1시에서 3시 사이에 전화 주세요`:  {`2021-03-01 13:00`, `2021-03-01 15:00`},
+		`3시와 5시 사이`:              {`2021-03-01 03:00`, `2021-03-01 05:00`},
+		`2021.03.05-2021.03.08`:  {`2021-03-05 00:00`, `2021-03-08 00:00`},
+		`23시 ~ 2시`:               {`2021-03-01 23:00`, `2021-03-02 02:00`},
+		`추석 연휴`:                  {`2021-09-20 00:00`, `2021-09-22 00:00`},
+	} {
+		if r, err := ExtractRange(str, true, WithReferenceTime(ref)); err == nil {
+			if r.From.Format("2006-01-02 15:04") != expected[0] || r.To.Format("2006-01-02 15:04") != expected[1] {
+				t.Errorf("ExtractRange extracted: %s ~ %s from string: '%s' (expected: %v)", r.From.Format("2006-01-02 15:04"), r.To.Format("2006-01-02 15:04"), str, expected)
+			}
+		} else {
+			t.Errorf("ExtractRange failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// positions
+	str := `일정: 3월 5일부터 7일까지, 그리고 10일`
+	if matches, err := ExtractRangeMatches(str, true, WithReferenceTime(ref)); err == nil {
+		if len(matches) != 1 || matches[0].Text != `3월 5일부터 7일까지` || matches[0].To.Text != `7일` {
+			t.Errorf("ExtractRangeMatches extracted: %+v from string: '%s'", matches, str)
+		}
+	} else {
+		t.Errorf("ExtractRangeMatches failed with string: '%s' (error: %s)", str, err)
+	}
+
+	// candidates of the end on the next day (eg: 2시 and 14시 of '23시 ~ 2시')
+	if matches, err := ExtractRangeMatches(`23시 ~ 2시`, true, WithReferenceTime(ref)); err == nil {
+		candidates := matches[0].To.Candidates
+		if len(candidates) != 2 || candidates[0].Format("2006-01-02 15:04") != `2021-03-02 02:00` || candidates[1].Format("2006-01-02 15:04") != `2021-03-02 14:00` {
+			t.Errorf("ExtractRangeMatches extracted candidates: %v", candidates)
+		}
+	} else {
+		t.Errorf("ExtractRangeMatches failed (error: %s)", err)
+	}
+
+	// not ranges
+	for _, str := range []string{
+		`3월 5일 그리고 7일`,
+		`3시와 5시에 알람`,
+		`내일 3시`,
+	} {
+		if r, err := ExtractRanges(str, true, WithReferenceTime(ref)); err == nil {
+			t.Errorf("ExtractRanges should fail with string: '%s' (extracted: %v)", str, r)
+		}
+	}
+}
@@ -0,0 +1,560 @@
+package lkdp
+
+// 반복 일정 표현
+//
+// eg: '매일 아침 8시', '매주 월요일 오후 3시', '매달 1일', '격주 금요일', '평일 9시', '주말마다'
+//
+// (iCalendar(RFC 5545)의 RRULE과 호환)
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the frequency of a recurrence (FREQ of RRULE)
+type Frequency int
+
+// frequencies
+const (
+	Yearly Frequency = iota
+	Monthly
+	Weekly
+	Daily
+)
+
+// String returns the RRULE representation of this frequency (eg: 'WEEKLY')
+func (f Frequency) String() string {
+	switch f {
+	case Yearly:
+		return "YEARLY"
+	case Monthly:
+		return "MONTHLY"
+	case Weekly:
+		return "WEEKLY"
+	case Daily:
+		return "DAILY"
+	}
+	return fmt.Sprintf("Frequency(%d)", int(f))
+}
+
+// RRULE representations of weekdays
+var rruleWeekdays = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+// WeekdayNum is a weekday with an optional ordinal (BYDAY of RRULE, eg: 'MO', '2FR', '-1SU')
+type WeekdayNum struct {
+	N       int // n-th weekday of the month (0 = every weekday, negative = from the end of the month)
+	Weekday time.Weekday
+}
+
+// String returns the RRULE representation of this weekday (eg: 'MO', '2FR', '-1SU')
+func (w WeekdayNum) String() string {
+	if w.N != 0 {
+		return strconv.Itoa(w.N) + rruleWeekdays[w.Weekday]
+	}
+	return rruleWeekdays[w.Weekday]
+}
+
+// Recurrence is a recurring schedule, compatible with RRULE of iCalendar (RFC 5545)
+//
+// (parts which are not given are taken from `Start`, as DTSTART of RRULE)
+type Recurrence struct {
+	Start time.Time // DTSTART: start of the recurrence (the first occurrence at or after the reference time, or on or after the reference date without times)
+
+	Frequency Frequency
+	Interval  int // eg: 2 for '격주' (0 or 1 = every)
+
+	ByMonth    []int
+	ByMonthDay []int // negative = from the end of the month (eg: -1 for '말일')
+	ByDay      []WeekdayNum
+	ByHour     []int
+	ByMinute   []int
+	BySecond   []int
+
+	WeekStart time.Weekday // WKST: first day of a week (for weekly recurrences with intervals)
+}
+
+// maximum number of days for searching the next occurrence
+const maxRecurrenceSearchDays = 366 * 100
+
+// RRule returns the RRULE representation of this recurrence (without DTSTART)
+//
+// eg: 'FREQ=WEEKLY;INTERVAL=2;BYDAY=FR;BYHOUR=15;BYMINUTE=0;BYSECOND=0'
+func (r Recurrence) RRule() string {
+	parts := []string{"FREQ=" + r.Frequency.String()}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	for _, by := range []struct {
+		name   string
+		values []int
+	}{
+		{"BYMONTH", r.ByMonth},
+		{"BYMONTHDAY", r.ByMonthDay},
+	} {
+		if len(by.values) > 0 {
+			parts = append(parts, by.name+"="+joinInts(by.values))
+		}
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	for _, by := range []struct {
+		name   string
+		values []int
+	}{
+		{"BYHOUR", r.ByHour},
+		{"BYMINUTE", r.ByMinute},
+		{"BYSECOND", r.BySecond},
+	} {
+		if len(by.values) > 0 {
+			parts = append(parts, by.name+"="+joinInts(by.values))
+		}
+	}
+	if r.Frequency == Weekly && r.Interval > 1 {
+		parts = append(parts, "WKST="+rruleWeekdays[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence after given time
+//
+// returns false if there is no such occurrence
+func (r Recurrence) Next(after time.Time) (next time.Time, exists bool) {
+	location := r.Start.Location()
+	after = after.In(location)
+
+	from := r.Start
+	if after.After(from) {
+		from = after
+	}
+
+	date := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, location)
+	for i := 0; i < maxRecurrenceSearchDays; i++ {
+		if r.matchesDate(date) {
+			for _, t := range r.timesOn(date) {
+				if t.After(after) && !t.Before(r.Start) {
+					return t, true
+				}
+			}
+		}
+		date = date.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}, false
+}
+
+// Occurrences returns upcoming occurrences (at most `count`) after given time
+func (r Recurrence) Occurrences(after time.Time, count int) (occurrences []time.Time) {
+	for len(occurrences) < count {
+		next, exists := r.Next(after)
+		if !exists {
+			break
+		}
+		occurrences = append(occurrences, next)
+		after = next
+	}
+	return occurrences
+}
+
+// check if given date is one of the occurrences' dates
+func (r Recurrence) matchesDate(date time.Time) bool {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	var periods int
+	switch r.Frequency {
+	case Daily:
+		periods = daysBetween(r.Start, date)
+	case Weekly:
+		periods = daysBetween(startOfWeek(r.Start, r.WeekStart), startOfWeek(date, r.WeekStart)) / 7
+	case Monthly:
+		periods = (date.Year()-r.Start.Year())*12 + int(date.Month()) - int(r.Start.Month())
+	case Yearly:
+		periods = date.Year() - r.Start.Year()
+	}
+	if periods%interval != 0 {
+		return false
+	}
+
+	if len(r.ByMonth) > 0 && !containsInt(r.ByMonth, int(date.Month())) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && !matchesMonthDay(r.ByMonthDay, date) {
+		return false
+	}
+	if len(r.ByDay) > 0 && !matchesWeekday(r.ByDay, date) {
+		return false
+	}
+
+	// parts which are not given are taken from the start
+	switch r.Frequency {
+	case Weekly:
+		if len(r.ByDay) <= 0 {
+			return date.Weekday() == r.Start.Weekday()
+		}
+	case Monthly:
+		if len(r.ByMonthDay) <= 0 && len(r.ByDay) <= 0 {
+			return date.Day() == r.Start.Day()
+		}
+	case Yearly:
+		if len(r.ByMonthDay) <= 0 && len(r.ByDay) <= 0 {
+			if len(r.ByMonth) <= 0 && date.Month() != r.Start.Month() {
+				return false
+			}
+			return date.Day() == r.Start.Day()
+		}
+	}
+
+	return true
+}
+
+// returns the times of occurrences on given date, in order
+func (r Recurrence) timesOn(date time.Time) (times []time.Time) {
+	hours, minutes, seconds := r.ByHour, r.ByMinute, r.BySecond
+	if len(hours) <= 0 {
+		hours = []int{r.Start.Hour()}
+	}
+	if len(minutes) <= 0 {
+		minutes = []int{r.Start.Minute()}
+	}
+	if len(seconds) <= 0 {
+		seconds = []int{r.Start.Second()}
+	}
+
+	for _, hour := range hours {
+		for _, minute := range minutes {
+			for _, second := range seconds {
+				times = append(times, time.Date(date.Year(), date.Month(), date.Day(), hour, minute, second, 0, date.Location()))
+			}
+		}
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	return times
+}
+
+// check if given date matches one of the days of the month (negative = from the end of the month)
+func matchesMonthDay(days []int, date time.Time) bool {
+	daysInMonth := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, date.Location()).Day()
+	for _, day := range days {
+		if day == date.Day() || (day < 0 && daysInMonth+day+1 == date.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+// check if given date matches one of the weekdays (with ordinals in the month)
+func matchesWeekday(weekdays []WeekdayNum, date time.Time) bool {
+	daysInMonth := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, date.Location()).Day()
+	for _, w := range weekdays {
+		if w.Weekday != date.Weekday() {
+			continue
+		}
+		if w.N == 0 ||
+			(w.N > 0 && (date.Day()-1)/7+1 == w.N) ||
+			(w.N < 0 && (daysInMonth-date.Day())/7+1 == -w.N) {
+			return true
+		}
+	}
+	return false
+}
+
+// check if given ints contain the value
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// join ints with commas (eg: '1,15,-1')
+func joinInts(values []int) string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = strconv.Itoa(v)
+	}
+	return strings.Join(strs, ",")
+}
+
+// rule for extracting recurrences
+type recurrenceRule struct {
+	name  string
+	re    *regexp.Regexp
+	parse func(e *extraction, slices []string) (recurrence Recurrence, ok bool)
+}
+
+// weekdays of recurrences
+var recurrenceWeekdays = map[string]time.Weekday{
+	ExpressionMonday1:    time.Monday,
+	ExpressionTuesday1:   time.Tuesday,
+	ExpressionWednesday1: time.Wednesday,
+	ExpressionThursday1:  time.Thursday,
+	ExpressionFriday1:    time.Friday,
+	ExpressionSaturday1:  time.Saturday,
+	ExpressionSunday1:    time.Sunday,
+}
+
+// ExtractRecurrenceMatches extracts all recurrences and their positions from given string
+//
+// recurrences are expressed as '매일', '매주 월요일', '격주 금요일', '매달 1일', '매월 둘째 주 화요일', '매년 3월 5일',
+// '평일', '주말마다', etc., optionally followed by a time (eg: '매일 아침 8시').
+// they start from their first occurrences at or after the reference time (as DTSTART), in the parser's location.
+//
+// ambiguous times (eg: '매일 3시') are resolved with the ambiguity policy like other times,
+// and their candidates are returned in `Candidates`.
+//
+// returned matches are ordered by their positions
+//
+// returns `nil` matches on error
+func (p *Parser) ExtractRecurrenceMatches(str string) (matches []RecurrenceMatch, err error) {
+	e := p.newExtraction(str, false)
+	matches = e.recurrences()
+
+	// invalid dates/times in strict mode
+	if err = e.rejection(); err != nil {
+		return nil, err
+	}
+
+	if len(matches) <= 0 {
+		return nil, e.noMatch(SubjectRecurrence)
+	}
+
+	return matches, nil
+}
+
+// ExtractRecurrences extracts all recurrences from given string
+//
+// (when the same text is matched multiple times, the first one is used)
+//
+// returns `nil` recurrences on error
+//
+// 주어진 한글 string으로부터 반복 일정 추출
+func (p *Parser) ExtractRecurrences(str string) (recurrences map[string]Recurrence, err error) {
+	var matches []RecurrenceMatch
+	if matches, err = p.ExtractRecurrenceMatches(str); err != nil {
+		return nil, err
+	}
+
+	recurrences = map[string]Recurrence{}
+	for _, m := range matches {
+		if _, exists := recurrences[m.Text]; !exists {
+			recurrences[m.Text] = m.Recurrence
+		}
+	}
+
+	return recurrences, nil
+}
+
+// ExtractRecurrence extracts recurrence from given string
+//
+// 주어진 한글 string으로부터 패턴에 가장 먼저 맞는 반복 일정 추출
+func (p *Parser) ExtractRecurrence(str string) (recurrence Recurrence, err error) {
+	var matches []RecurrenceMatch
+	if matches, err = p.ExtractRecurrenceMatches(str); err != nil {
+		return Recurrence{}, err
+	}
+
+	// the left-most(with the least index) matched recurrence
+	return matches[0].Recurrence, nil
+}
+
+// extract all recurrences
+func (e *extraction) recurrences() (matches []RecurrenceMatch) {
+	var all []RecurrenceMatch
+	var candidates []candidate
+	for priority, r := range e.rules.recurrences {
+		for _, indices := range r.re.FindAllStringSubmatchIndex(e.normalized, -1) {
+			slices := submatches(e.normalized, indices)
+			span := e.span(indices[0], indices[1])
+
+			e.debugPrint("%s: matched string = '%s', slices = [%s]", r.name, span.Text, strings.Join(slices, ", "))
+
+			if recurrence, ok := r.parse(e, slices); ok {
+				// (recurrences on non-existent dates never occur, so they are always rejected)
+				if invalid := recurrence.invalid(); invalid != nil {
+					e.reject(SubjectRecurrence, span, invalid)
+					continue
+				}
+
+				candidates = append(candidates, candidate{Span: span, priority: priority, index: len(all)})
+				all = append(all, RecurrenceMatch{Span: span, Recurrence: recurrence, now: e.now})
+			}
+		}
+	}
+
+	times := e.times()
+	for _, c := range resolveOverlaps(candidates) {
+		m := all[c.index]
+
+		// with the time right after it (and the candidates of an ambiguous time)
+		timed := false
+		for _, t := range times {
+			if t.Start < m.End {
+				continue
+			}
+			if recurrenceTimeGapRe.MatchString(e.str[m.End:t.Start]) {
+				m.Span, timed = newSpan(e.str, m.Start, t.End), true
+				for _, c := range t.Candidates {
+					m.Candidates = append(m.Candidates, e.startRecurrence(m.Recurrence.at(c), true))
+				}
+				m.Recurrence = m.Recurrence.at(t.Hms)
+			}
+			break
+		}
+		m.Recurrence = e.startRecurrence(m.Recurrence, timed)
+
+		e.debugPrint("extracted recurrence = %s from '%s'", m.Recurrence.RRule(), m.Text)
+
+		matches = append(matches, m)
+	}
+
+	return matches
+}
+
+// returns a copy of this recurrence at given time
+func (r Recurrence) at(hms Hms) Recurrence {
+	r.Start = time.Date(r.Start.Year(), r.Start.Month(), r.Start.Day(), hms.Hours, hms.Minutes, hms.Seconds, 0, r.Start.Location())
+	r.ByHour, r.ByMinute, r.BySecond = []int{hms.Hours}, []int{hms.Minutes}, []int{hms.Seconds}
+	return r
+}
+
+// returns given recurrence starting from its first occurrence at or after the reference time
+// (or on or after the reference date, when it is not `timed`)
+func (e *extraction) startRecurrence(r Recurrence, timed bool) Recurrence {
+	from := time.Date(e.now.Year(), e.now.Month(), e.now.Day(), 0, 0, 0, 0, e.location)
+	if timed {
+		from = e.now
+	}
+	if first, exists := r.Next(from.Add(-time.Nanosecond)); exists {
+		r.Start = first
+	}
+	return r
+}
+
+// returns a new recurrence which starts from the reference date
+func (e *extraction) newRecurrence(frequency Frequency, interval int) Recurrence {
+	return Recurrence{
+		Start:     time.Date(e.now.Year(), e.now.Month(), e.now.Day(), 0, 0, 0, 0, e.location),
+		Frequency: frequency,
+		Interval:  interval,
+		WeekStart: e.weekStart,
+	}
+}
+
+// parse a list of weekdays (eg: '화요일과 목요일' => TU, TH)
+func parseWeekdayList(str string) (weekdays []WeekdayNum) {
+	for _, slices := range recurrenceWeekdayRe.FindAllStringSubmatch(str, -1) {
+		weekdays = append(weekdays, WeekdayNum{Weekday: recurrenceWeekdays[slices[1]]})
+	}
+	return weekdays
+}
+
+// '매일', '날마다', '격일', '이틀마다' 등
+func parseRecurrenceDaily(e *extraction, slices []string) (Recurrence, bool) {
+	interval := 1
+	if slices[1] == ExpressionEveryOtherDay1 {
+		interval = 2
+	} else if slices[2] != "" {
+		interval, _ = strconv.Atoi(slices[2])
+	}
+	if interval <= 0 {
+		return Recurrence{}, false
+	}
+
+	return e.newRecurrence(Daily, interval), true
+}
+
+// '평일', '주말마다' 등
+func parseRecurrenceWeekdays(e *extraction, slices []string) (Recurrence, bool) {
+	r := e.newRecurrence(Weekly, 1)
+	if strings.HasPrefix(slices[1], ExpressionWeekend1) || strings.HasPrefix(slices[1], ExpressionEvery1) {
+		r.ByDay = []WeekdayNum{{Weekday: time.Saturday}, {Weekday: time.Sunday}}
+	} else {
+		r.ByDay = []WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Tuesday}, {Weekday: time.Wednesday}, {Weekday: time.Thursday}, {Weekday: time.Friday}}
+	}
+
+	return r, true
+}
+
+// '매주 월요일', '격주 금요일', '2주마다', '화요일마다' 등
+func parseRecurrenceWeekly(e *extraction, slices []string) (Recurrence, bool) {
+	interval := 1
+	if slices[1] == ExpressionEveryOtherWeek1 {
+		interval = 2
+	} else if slices[2] != "" {
+		interval, _ = strconv.Atoi(slices[2])
+	}
+	if interval <= 0 {
+		return Recurrence{}, false
+	}
+
+	r := e.newRecurrence(Weekly, interval)
+	r.ByDay = parseWeekdayList(slices[3] + slices[4])
+
+	return r, true
+}
+
+// '매달 1일', '매월 말일', '3개월마다', '매월 둘째 주 화요일', '매달 마지막 금요일' 등
+func parseRecurrenceMonthly(e *extraction, slices []string) (Recurrence, bool) {
+	interval := 1
+	if slices[2] != "" {
+		interval, _ = strconv.Atoi(slices[2])
+	}
+	if interval <= 0 {
+		return Recurrence{}, false
+	}
+
+	r := e.newRecurrence(Monthly, interval)
+	if slices[3] != "" {
+		day, _ := strconv.Atoi(slices[3])
+		if day < 1 || day > 31 {
+			return Recurrence{}, false
+		}
+		r.ByMonthDay = []int{day}
+	} else if slices[4] != "" {
+		r.ByMonthDay = []int{-1}
+	} else if slices[8] != "" {
+		n, ok := ordinalOf(slices[5], slices[6], slices[7])
+		if !ok || n > 5 {
+			return Recurrence{}, false
+		}
+		r.ByDay = []WeekdayNum{{N: n, Weekday: recurrenceWeekdays[slices[8]]}}
+	}
+
+	return r, true
+}
+
+// '매년 3월 5일', '해마다' 등
+func parseRecurrenceYearly(e *extraction, slices []string) (Recurrence, bool) {
+	r := e.newRecurrence(Yearly, 1)
+	if slices[1] != "" {
+		month, _ := strconv.Atoi(slices[1])
+		day, _ := strconv.Atoi(slices[2])
+		r.ByMonth, r.ByMonthDay = []int{month}, []int{day}
+	}
+
+	return r, true
+}
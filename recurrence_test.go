@@ -0,0 +1,131 @@
+package lkdp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExtractRecurrences(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 9, 0, 0, 0, loc) // Monday
+
+	for str, expected := range map[string]struct {
+		rrule       string
+		occurrences []string
+	}{
+		`매일 아침 8시에 알려줘`: {`FREQ=DAILY;BYHOUR=8;BYMINUTE=0;BYSECOND=0`, []string{`2021-03-02 08:00`, `2021-03-03 08:00`}},
+		`매일 저녁 7시 반`:    {`FREQ=DAILY;BYHOUR=19;BYMINUTE=30;BYSECOND=0`, []string{`2021-03-01 19:30`, `2021-03-02 19:30`}},
+		`매주 월요일 오후 3시`:  {`FREQ=WEEKLY;BYDAY=MO;BYHOUR=15;BYMINUTE=0;BYSECOND=0`, []string{`2021-03-01 15:00`, `2021-03-08 15:00`}},
+		`매달 1일`:         {`FREQ=MONTHLY;BYMONTHDAY=1`, []string{`2021-04-01 00:00`, `2021-05-01 00:00`}},
+		`매월 말일`:         {`FREQ=MONTHLY;BYMONTHDAY=-1`, []string{`2021-03-31 00:00`, `2021-04-30 00:00`}},
+		`격주 금요일`:        {`FREQ=WEEKLY;INTERVAL=2;BYDAY=FR;WKST=MO`, []string{`2021-03-05 00:00`, `2021-03-19 00:00`, `2021-04-02 00:00`}},
+		`평일 9시`:         {`FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0;BYSECOND=0`, []string{`2021-03-02 09:00`, `2021-03-03 09:00`}},
+		`주말마다 등산`:       {`FREQ=WEEKLY;BYDAY=SA,SU`, []string{`2021-03-06 00:00`, `2021-03-07 00:00`, `2021-03-13 00:00`}},
+		`매주 월, 수, 금요일`:  {`FREQ=WEEKLY;BYDAY=MO,WE,FR`, []string{`2021-03-03 00:00`, `2021-03-05 00:00`, `2021-03-08 00:00`}},
+		`화요일과 목요일마다`:    {`FREQ=WEEKLY;BYDAY=TU,TH`, []string{`2021-03-02 00:00`, `2021-03-04 00:00`}},
+		`이틀마다`:          {`FREQ=DAILY;INTERVAL=2`, []string{`2021-03-03 00:00`, `2021-03-05 00:00`}},
+		`매년 3월 5일`:      {`FREQ=YEARLY;BYMONTH=3;BYMONTHDAY=5`, []string{`2021-03-05 00:00`, `2022-03-05 00:00`}},
+	} {
+		if r, err := ExtractRecurrence(str, WithReferenceTime(ref)); err == nil {
+			if r.RRule() != expected.rrule {
+				t.Errorf("ExtractRecurrence extracted: %s from string: '%s' (expected: %s)", r.RRule(), str, expected.rrule)
+			}
+			occurrences := r.Occurrences(ref, len(expected.occurrences))
+			for i, o := range occurrences {
+				if o.Format("2006-01-02 15:04") != expected.occurrences[i] || o.Location().String() != loc.String() {
+					t.Errorf("Occurrences returned: %v for string: '%s' (expected: %v)", occurrences, str, expected.occurrences)
+					break
+				}
+			}
+		} else {
+			t.Errorf("ExtractRecurrence failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// not recurrences
+	for _, str := range []string{
+		`내일 3시`,
+		`이번 주말에 보자`,
+	} {
+		if r, err := ExtractRecurrences(str, WithReferenceTime(ref)); err == nil {
+			t.Errorf("ExtractRecurrences should fail with string: '%s' (extracted: %v)", str, r)
+		}
+	}
+
+	// non-existent dates
+	for _, str := range []string{
+		`매년 2월 30일`,
+		`매년 4월 31일에 보자`,
+		`매년 13월 1일`,
+	} {
+		if r, err := ExtractRecurrence(str, WithReferenceTime(ref)); !errors.Is(err, ErrInvalidDate) {
+			t.Errorf("ExtractRecurrence should fail with ErrInvalidDate with string: '%s' (extracted: %v, error: %v)", str, r, err)
+		}
+	}
+	if r, err := ExtractRecurrence(`매년 2월 29일`, WithReferenceTime(ref)); err != nil || r.Start.Format("2006-01-02") != `2024-02-29` {
+		t.Errorf("ExtractRecurrence extracted: %+v (error: %v)", r, err)
+	}
+}
+
+func TestRecurrenceOrdinalWeekdays(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	r := Recurrence{
+		Start:     time.Date(2021, 3, 1, 10, 0, 0, 0, loc),
+		Frequency: Monthly,
+		ByDay:     []WeekdayNum{{N: 2, Weekday: time.Tuesday}, {N: -1, Weekday: time.Friday}},
+	}
+	if r.RRule() != `FREQ=MONTHLY;BYDAY=2TU,-1FR` {
+		t.Errorf("RRule returned: %s", r.RRule())
+	}
+	occurrences := r.Occurrences(r.Start, 3)
+	for i, expected := range []string{`2021-03-09 10:00`, `2021-03-26 10:00`, `2021-04-13 10:00`} {
+		if occurrences[i].Format("2006-01-02 15:04") != expected {
+			t.Errorf("Occurrences returned: %v", occurrences)
+			break
+		}
+	}
+}
+
+func TestRecurrenceStartsAndAmbiguousTimes(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 10, 0, 0, 0, loc) // Monday
+
+	// starts from the first occurrence at or after the reference time
+	for str, expected := range map[string]string{
+		`매일 아침 8시`:     `2021-03-02 08:00`,
+		`매일 오전 11시`:    `2021-03-01 11:00`,
+		`매주 월요일 오전 9시`: `2021-03-08 09:00`,
+		`격주 금요일`:       `2021-03-05 00:00`,
+		`매일`:           `2021-03-01 00:00`,
+	} {
+		if r, err := ExtractRecurrence(str, WithReferenceTime(ref)); err != nil || r.Start.Format("2006-01-02 15:04") != expected {
+			t.Errorf("ExtractRecurrence extracted: %+v from string: '%s' (expected start: %s, error: %v)", r, str, expected, err)
+		}
+	}
+
+	// ambiguous times without a policy: with candidates
+	if matches, err := ExtractRecurrenceMatches(`매일 3시`, WithReferenceTime(ref)); err == nil {
+		m := matches[0]
+		if len(m.Candidates) != 2 || m.Candidates[0].RRule() != `FREQ=DAILY;BYHOUR=3;BYMINUTE=0;BYSECOND=0` || m.Candidates[1].RRule() != `FREQ=DAILY;BYHOUR=15;BYMINUTE=0;BYSECOND=0` {
+			t.Errorf("ExtractRecurrenceMatches extracted candidates: %+v", m.Candidates)
+		} else if m.Candidates[1].Start.Format("2006-01-02 15:04") != `2021-03-01 15:00` || m.Recurrence.RRule() != m.Candidates[0].RRule() {
+			t.Errorf("ExtractRecurrenceMatches extracted: %+v", m)
+		}
+	} else {
+		t.Errorf("ExtractRecurrenceMatches failed (error: %s)", err)
+	}
+
+	// with policies
+	if r, err := ExtractRecurrence(`매일 3시`, WithReferenceTime(ref), WithAmbiguityPolicy(PreferBusinessHours)); err != nil || r.RRule() != `FREQ=DAILY;BYHOUR=15;BYMINUTE=0;BYSECOND=0` || r.Start.Format("2006-01-02 15:04") != `2021-03-01 15:00` {
+		t.Errorf("ExtractRecurrence extracted: %+v (error: %v)", r, err)
+	}
+	if _, err := ExtractRecurrence(`매일 3시`, WithReferenceTime(ref), WithAmbiguityPolicy(RejectAmbiguous)); !errors.Is(err, ErrAmbiguous) {
+		t.Errorf("ExtractRecurrence should fail with ErrAmbiguous (error: %v)", err)
+	}
+
+	// not ambiguous
+	if matches, err := ExtractRecurrenceMatches(`매일 오후 3시`, WithReferenceTime(ref)); err != nil || matches[0].Candidates != nil {
+		t.Errorf("ExtractRecurrenceMatches extracted: %+v (error: %v)", matches, err)
+	}
+}
@@ -0,0 +1,204 @@
+package lkdp
+
+// 상대적인 기간 표현
+//
+// eg: '이번 달', '다음 달 15일', '지난달 말', '이번 주말', '다음 주', '올해 말', '연말', '월초', '내년 3월'
+
+import (
+	"strconv"
+	"time"
+)
+
+// returns the number of periods (months, weeks) from this one for given relative word (eg: 1 for '다음', 0 for '이번')
+func relativePeriods(word string) int {
+	switch word {
+	case ExpressionWeekAfterNext1: // the one after next
+		return 2
+	case ExpressionWeekNext1, ExpressionWeekNext2, ExpressionWeekNext3: // next
+		return 1
+	case ExpressionWeekBefore1, ExpressionWeekBefore2: // last
+		return -1
+	case ExpressionWeekBeforeLast1: // the one before last
+		return -2
+	}
+	return 0 // this (eg: '이번', '금')
+}
+
+// returns the number of months from this one for given relative words (eg: '다음' + '달', '익월')
+func relativeMonths(relative, relative2 string) int {
+	switch relative2 {
+	case ExpressionMonthBefore1 + ExpressionMonth1: // '전월'
+		return -1
+	case ExpressionMonthNext1 + ExpressionMonth1: // '익월'
+		return 1
+	}
+	return relativePeriods(relative)
+}
+
+// returns the match of a part of given period (eg: '초', '말일'), or false if it is not a part
+//
+// 초(초순): 1일 ~ 10일, 중순: 11일 ~ 20일, 말(하순): 21일 ~ 말일, 말일: 마지막 날
+func partOfMonth(first time.Time, part string) (DateMatch, bool) {
+	last := first.AddDate(0, 1, -1)
+
+	switch part {
+	case ExpressionEarly1, ExpressionEarly2:
+		return DateMatch{Date: first, Until: first.AddDate(0, 0, 9)}, true
+	case ExpressionMiddle1:
+		return DateMatch{Date: first.AddDate(0, 0, 10), Until: first.AddDate(0, 0, 19)}, true
+	case ExpressionLate1, ExpressionLate2:
+		return DateMatch{Date: first.AddDate(0, 0, 20), Until: last}, true
+	case ExpressionLastDay1:
+		return DateMatch{Date: last}, true
+	}
+	return DateMatch{}, false
+}
+
+// '이번 달', '다음 달 15일', '지난달 말', '익월 초', '3월 말', '월말' 등
+//
+// 일(日)이나 초/중순/말 없이 주어진 경우 해당 월 전체의 기간으로 계산
+func parseDateRelMonth1(e *extraction, slices []string) (DateMatch, bool) {
+	yearStr, monthStr, relative, relative2, bare := slices[1], slices[2], slices[3], slices[4], slices[5]
+	dayStr, part := slices[6], slices[7]
+
+	var first time.Time
+	var missing dateParts
+	switch {
+	case monthStr != "": // '3월 말', '2021년 3월 초' (without parts: by other rules)
+		if part == "" {
+			return DateMatch{}, false
+		}
+		month64, _ := strconv.ParseInt(monthStr, 10, 16)
+		if month64 < 1 || month64 > 12 {
+			return DateMatch{invalid: newError(ErrInvalidDate, "%d월은 존재하지 않습니다", month64)}, true
+		}
+
+		year := 0
+		if yearStr != "" {
+			var ok bool
+			if year, ok = e.year(yearStr); !ok {
+				return DateMatch{invalid: newError(ErrOutOfRange, "%s년은 범위를 벗어났습니다", yearStr)}, true
+			}
+		} else {
+			missing = dateYear
+			if e.fill {
+				year = e.now.Year()
+			}
+		}
+		first = time.Date(year, time.Month(month64), 1, 0, 0, 0, 0, e.location)
+	case bare != "": // '월초', '월말' (only with parts)
+		if part == "" {
+			return DateMatch{}, false
+		}
+		first = time.Date(e.now.Year(), e.now.Month(), 1, 0, 0, 0, 0, e.location)
+	default: // '이번 달', '다음 달', '익월', ...
+		first = time.Date(e.now.Year(), e.now.Month()+time.Month(relativeMonths(relative, relative2)), 1, 0, 0, 0, 0, e.location)
+	}
+
+	var m DateMatch
+	switch {
+	case dayStr != "": // '다음 달 15일'
+		day64, _ := strconv.ParseInt(dayStr, 10, 16)
+		m = DateMatch{Date: first.AddDate(0, 0, int(day64)-1), invalid: validateDate(first.Year(), int(first.Month()), int(day64))}
+	case part != "": // '다음 달 초', '이번 달 말일'
+		m, _ = partOfMonth(first, part)
+	default: // '이번 달'
+		m = DateMatch{Date: first, Until: first.AddDate(0, 1, -1), Granularity: GranularityMonth}
+	}
+	m.missing = missing
+
+	return m, true
+}
+
+// '이번 주', '다음 주', '지난주', '이번 주말', '차주', '주말' 등
+//
+// '주말'만 주어진 경우 오늘을 포함하여 다가오는 주말(토, 일)로 계산
+func parseDateRelWeek1(e *extraction, slices []string) (DateMatch, bool) {
+	relative, relative2, weekend, bare := slices[1], slices[2], slices[3] != "", slices[4] != ""
+
+	today := time.Date(e.now.Year(), e.now.Month(), e.now.Day(), 0, 0, 0, 0, e.location)
+
+	if bare { // upcoming weekend (including today)
+		saturday := today.AddDate(0, 0, -((int(today.Weekday()) - int(time.Saturday) + 7) % 7))
+		if saturday.AddDate(0, 0, 1).Before(today) {
+			saturday = saturday.AddDate(0, 0, 7)
+		}
+		return DateMatch{Date: saturday, Until: saturday.AddDate(0, 0, 1)}, true
+	}
+
+	first := startOfWeek(today, e.weekStart).AddDate(0, 0, relativePeriods(relative+relative2)*7)
+	if weekend { // saturday of the week and the following sunday
+		saturday := first.AddDate(0, 0, (int(time.Saturday)-int(e.weekStart)+7)%7)
+		return DateMatch{Date: saturday, Until: saturday.AddDate(0, 0, 1)}, true
+	}
+
+	return DateMatch{Date: first, Until: first.AddDate(0, 0, 6), Granularity: GranularityWeek}, true
+}
+
+// '올해', '올해 말', '내년 3월', '작년 12월 25일', '연초', '2021년 말' 등
+//
+// 월이나 초/말 없이 주어진 경우 해당 연도 전체의 기간으로 계산 ('내년', '작년' 등 단독으로 쓰인 경우는 제외)
+func parseDateRelYear1(e *extraction, slices []string) (DateMatch, bool) {
+	yearStr, relative, bare := slices[1], slices[2], slices[3]
+	monthStr, dayStr, part := slices[4], slices[5], slices[6]
+
+	var year int
+	switch {
+	case yearStr != "": // '2021년 말' (only with parts)
+		if part == "" {
+			return DateMatch{}, false
+		}
+		var ok bool
+		if year, ok = e.year(yearStr); !ok {
+			return DateMatch{invalid: newError(ErrOutOfRange, "%s년은 범위를 벗어났습니다", yearStr)}, true
+		}
+	case bare != "": // '연초', '연말' (only with parts)
+		if part == "" {
+			return DateMatch{}, false
+		}
+		year = e.now.Year()
+	default:
+		year = e.now.Year()
+		switch relative {
+		case ExpressionYearThis3: // '올' (only with months or parts)
+			if monthStr == "" && part == "" {
+				return DateMatch{}, false
+			}
+		case ExpressionYearNext:
+			year++
+		case ExpressionYearAfterNext:
+			year += 2
+		case ExpressionYearBefore, ExpressionYearBefore2:
+			year--
+		case ExpressionYearBeforeLast, ExpressionYearBeforeLast2:
+			year -= 2
+		}
+	}
+
+	switch {
+	case monthStr != "" && dayStr != "": // '내년 3월 5일'
+		month64, _ := strconv.ParseInt(monthStr, 10, 16)
+		day64, _ := strconv.ParseInt(dayStr, 10, 16)
+		return DateMatch{
+			Date:    time.Date(year, time.Month(month64), int(day64), 0, 0, 0, 0, e.location),
+			invalid: validateDate(year, int(month64), int(day64)),
+		}, true
+	case monthStr != "": // '내년 3월'
+		month64, _ := strconv.ParseInt(monthStr, 10, 16)
+		if month64 < 1 || month64 > 12 {
+			return DateMatch{invalid: newError(ErrInvalidDate, "%d월은 존재하지 않습니다", month64)}, true
+		}
+		from, to := GranularityMonth.period(time.Date(year, time.Month(month64), 1, 0, 0, 0, 0, e.location), e.weekStart)
+		return DateMatch{Date: from, Until: to, Granularity: GranularityMonth, missing: dateDay}, true
+	case part == ExpressionEarly2: // '올해 초' => 1월
+		from, to := GranularityMonth.period(time.Date(year, time.January, 1, 0, 0, 0, 0, e.location), e.weekStart)
+		return DateMatch{Date: from, Until: to, Granularity: GranularityMonth}, true
+	case part == ExpressionLate2: // '올해 말' => 12월
+		from, to := GranularityMonth.period(time.Date(year, time.December, 1, 0, 0, 0, 0, e.location), e.weekStart)
+		return DateMatch{Date: from, Until: to, Granularity: GranularityMonth}, true
+	}
+
+	// '올해'
+	from, to := GranularityYear.period(time.Date(year, time.January, 1, 0, 0, 0, 0, e.location), e.weekStart)
+	return DateMatch{Date: from, Until: to, Granularity: GranularityYear, missing: dateMonth | dateDay}, true
+}
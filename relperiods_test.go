@@ -0,0 +1,96 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativePeriods(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 10, 9, 0, 0, 0, loc) // Wednesday
+
+	for str, expected := range map[string]struct {
+		text        string
+		from, until string
+	}{
+		`이번 달`:         {`이번 달`, `2021-03-01`, `2021-03-31`},
+		`다음 달 15일에 보자`: {`다음 달 15일`, `2021-04-15`, ``},
+		`지난달 15일`:      {`지난달 15일`, `2021-02-15`, ``},
+		`지난달 말`:        {`지난달 말`, `2021-02-21`, `2021-02-28`},
+		`다음 달 초`:       {`다음 달 초`, `2021-04-01`, `2021-04-10`},
+		`이번 달 중순`:      {`이번 달 중순`, `2021-03-11`, `2021-03-20`},
+		`이번 달 말일까지`:    {`이번 달 말일`, `2021-03-31`, ``},
+		`익월 초`:         {`익월 초`, `2021-04-01`, `2021-04-10`},
+		`전월`:           {`전월`, `2021-02-01`, `2021-02-28`},
+		`월말 정산`:        {`월말`, `2021-03-21`, `2021-03-31`},
+		`월초`:           {`월초`, `2021-03-01`, `2021-03-10`},
+		`3월 말`:         {`3월 말`, `2021-03-21`, `2021-03-31`},
+		`2020년 12월 초`:  {`2020년 12월 초`, `2020-12-01`, `2020-12-10`},
+		`이번 주`:         {`이번 주`, `2021-03-08`, `2021-03-14`},
+		`다음 주에 만나`:     {`다음 주`, `2021-03-15`, `2021-03-21`},
+		`지난주`:          {`지난주`, `2021-03-01`, `2021-03-07`},
+		`차주`:           {`차주`, `2021-03-15`, `2021-03-21`},
+		`이번 주말`:        {`이번 주말`, `2021-03-13`, `2021-03-14`},
+		`다음 주말에`:       {`다음 주말`, `2021-03-20`, `2021-03-21`},
+		`주말에 뭐해`:       {`주말`, `2021-03-13`, `2021-03-14`},
+		`올해`:           {`올해`, `2021-01-01`, `2021-12-31`},
+		`올해 말까지`:       {`올해 말`, `2021-12-01`, `2021-12-31`},
+		`연말`:           {`연말`, `2021-12-01`, `2021-12-31`},
+		`연초`:           {`연초`, `2021-01-01`, `2021-01-31`},
+		`내년 3월`:        {`내년 3월`, `2022-03-01`, `2022-03-31`},
+		`작년 12월 25일`:   {`작년 12월 25일`, `2020-12-25`, ``},
+		`2022년 말`:      {`2022년 말`, `2022-12-01`, `2022-12-31`},
+		`올 3월`:         {`올 3월`, `2021-03-01`, `2021-03-31`},
+		`재작년`:          {`재작년`, `2019-01-01`, `2019-12-31`},
+		`내년`:           {`내년`, `2022-03-10`, ``}, // (as before)
+	} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+			m := matches[0]
+			until := ""
+			if !m.Until.IsZero() {
+				until = m.Until.Format("2006-01-02")
+			}
+			if m.Text != expected.text || m.Date.Format("2006-01-02") != expected.from || until != expected.until {
+				t.Errorf("ExtractDateMatches extracted: '%s' %s ~ %s from string: '%s' (expected: %+v)", m.Text, m.Date.Format("2006-01-02"), until, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// not dates
+	for _, str := range []string{`올라간다`, `연휴가 길다`, `월요병`} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+			t.Errorf("ExtractDateMatches should fail with string: '%s' (extracted: %+v)", str, matches)
+		}
+	}
+
+	// weeks starting from sunday
+	for str, expected := range map[string][]string{
+		`이번 주`:  {`2021-03-07`, `2021-03-13`},
+		`이번 주말`: {`2021-03-13`, `2021-03-14`},
+	} {
+		if r, err := ExtractRange(str, true, WithReferenceTime(ref), WithWeekStart(time.Sunday)); err != nil || r.From.Format("2006-01-02") != expected[0] || r.To.Format("2006-01-02") != expected[1] {
+			t.Errorf("ExtractRange extracted: %s ~ %s from string: '%s' (expected: %v, error: %v)", r.From.Format("2006-01-02"), r.To.Format("2006-01-02"), str, expected, err)
+		}
+	}
+
+	// weekend on sunday
+	sunday := time.Date(2021, 3, 14, 9, 0, 0, 0, loc)
+	if r, err := ExtractRange(`주말`, true, WithReferenceTime(sunday)); err != nil || r.From.Format("2006-01-02") != `2021-03-13` || r.To.Format("2006-01-02") != `2021-03-14` {
+		t.Errorf("ExtractRange returned: %+v (error: %v)", r, err)
+	}
+
+	// with times
+	if dt, err := ExtractDateTime(`다음 달 15일 오후 3시`, true, WithReferenceTime(ref)); err != nil || dt.Format("2006-01-02 15:04") != `2021-04-15 15:00` {
+		t.Errorf("ExtractDateTime returned: %s (error: %v)", dt, err)
+	}
+
+	// invalid days
+	if matches, err := ExtractDateMatches(`다음 달 31일`, true, WithReferenceTime(ref)); err != nil || !matches[0].Normalized {
+		t.Errorf("ExtractDateMatches returned: %+v (error: %v)", matches, err)
+	}
+	if _, err := ExtractDateMatches(`다음 달 31일`, true, WithReferenceTime(ref), WithStrict(true)); err == nil {
+		t.Errorf("ExtractDateMatches should fail in strict mode")
+	}
+}
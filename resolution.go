@@ -0,0 +1,130 @@
+package lkdp
+
+// 불완전한 날짜/시간의 해석
+//
+// eg: (2021년 12월 기준) '1월 3일' => 2022년 1월 3일, '1월 3일에 만났다' => 2021년 1월 3일
+
+import (
+	"time"
+)
+
+// Resolution is the way of resolving incomplete dates and times (eg: '1월 3일' without its year, '3시' without its date)
+type Resolution int
+
+// resolutions of incomplete dates and times
+const (
+	ResolveReferenceDate  Resolution = iota // fill missing parts with the reference date's (default)
+	ResolveNextOccurrence                   // the nearest future occurrence, or the nearest past one in past-tense sentences
+)
+
+// returns the nearest occurrence of given date match, which is missing its year (and month)
+//
+// (the nearest one from today, including today, in the future or in the past)
+func (e *extraction) nearestDate(m DateMatch, past bool) DateMatch {
+	if m.missing&dateYear == 0 {
+		return m
+	}
+	if m.invalid != nil {
+		return e.nearestValidDate(m, past)
+	}
+
+	today := time.Date(e.now.Year(), e.now.Month(), e.now.Day(), 0, 0, 0, 0, e.location)
+
+	nearest := m
+	for n := -1; n <= 1; n++ {
+		var moved DateMatch
+		var ok bool
+		if m.missing&dateMonth != 0 { // (eg: '15일')
+			date := m.Date.AddDate(0, n, 0)
+			moved, ok = m.movedTo(date), date.Day() == m.Date.Day()
+		} else { // (eg: '1월 3일', '3월', '3월 첫째 주 월요일')
+			moved, ok = m.inYear(m.Date.Year() + n)
+		}
+		if !ok { // not in the month (eg: '31일' in april, '2월 29일' in 2021)
+			continue
+		}
+
+		if past {
+			if !moved.Date.After(today) {
+				nearest = moved
+			}
+		} else {
+			last := moved.Date
+			if !moved.Until.IsZero() {
+				last = moved.Until
+			}
+			if !last.Before(today) {
+				return moved
+			}
+		}
+	}
+
+	if nearest.Date != m.Date {
+		e.debugPrint("resolution: resolved %s as %s", m.Text, nearest.Date.Format("2006-01-02"))
+	}
+
+	return nearest
+}
+
+// returns the nearest occurrence of given invalid date match, in the years (or months) in which it exists
+// (eg: '2월 29일' => 2024년 2월 29일 in 2021, '31일' => 3월 31일 in february)
+//
+// (returns the match as it is if there is none, eg: '2월 30일')
+func (e *extraction) nearestValidDate(m DateMatch, past bool) DateMatch {
+	if m.day <= 0 || (m.missing&dateMonth == 0 && (m.month < 1 || m.month > 12)) { // (not in any year, eg: '0월 5일', '13월 5일')
+		return m
+	}
+
+	today := time.Date(e.now.Year(), e.now.Month(), e.now.Day(), 0, 0, 0, 0, e.location)
+
+	step := 1
+	if past {
+		step = -1
+	}
+	for n := 0; n <= 8; n++ { // (february 29 exists at least once in 8 years)
+		first := time.Date(e.now.Year()+step*n, time.Month(m.month), 1, 0, 0, 0, 0, e.location)
+		if m.missing&dateMonth != 0 { // (eg: '31일')
+			first = time.Date(e.now.Year(), e.now.Month()+time.Month(step*n), 1, 0, 0, 0, 0, e.location)
+		}
+		if m.day > daysIn(first.Year(), first.Month()) {
+			continue
+		}
+
+		date := first.AddDate(0, 0, m.day-1)
+		if (past && date.After(today)) || (!past && date.Before(today)) {
+			continue
+		}
+
+		e.debugPrint("resolution: resolved invalid %s as %s", m.Text, date.Format("2006-01-02"))
+
+		resolved := m.movedTo(date)
+		resolved.invalid = nil
+		return resolved
+	}
+
+	return m
+}
+
+// returns the date of the nearest occurrence of given time without a date
+//
+// (the nearest one from now, in the future or in the past)
+func (e *extraction) nearestDateOfTime(hms Hms, past bool) time.Time {
+	today := time.Date(e.now.Year(), e.now.Month(), e.now.Day(), 0, 0, 0, 0, e.location)
+
+	t := e.combine(today, hms)
+	switch {
+	case past && t.After(e.now):
+		return today.AddDate(0, 0, -1)
+	case !past && t.Before(e.now):
+		return today.AddDate(0, 0, 1)
+	}
+	return today
+}
+
+// returns given date/time candidates of an ambiguous time, ordered from the nearest one
+// (eg: 15:00 today, then 03:00 tomorrow for '3시' at 10:00)
+//
+// (the nearest one in the past comes first in past-tense sentences)
+func (e *extraction) nearestCandidates(candidates []time.Time, tense Tense) []time.Time {
+	return preferFuture{}.RankByTense(candidates, e.now, tense)
+}
@@ -0,0 +1,115 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextOccurrenceDates(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 12, 20, 10, 0, 0, 0, loc)
+
+	for str, expected := range map[string]struct {
+		from, until string
+	}{
+		`1월 3일에 만나자`:          {`2022-01-03`, ``},
+		`1월 3일에 만났다`:          {`2021-01-03`, ``},
+		`12월 25일`:             {`2021-12-25`, ``},
+		`12월 25일에 갔었어`:        {`2020-12-25`, ``},
+		`12월 20일에 했다`:         {`2021-12-20`, ``},
+		`5일까지 내세요`:            {`2022-01-05`, ``},
+		`5일에 냈습니다`:            {`2021-12-05`, ``},
+		`31일에 보자`:             {`2021-12-31`, ``},
+		`3월에 출시`:              {`2022-03-01`, `2022-03-31`},
+		`12월에 출시`:             {`2021-12-01`, `2021-12-31`},
+		`3월 말까지 하자`:           {`2022-03-21`, `2022-03-31`},
+		`1월 3일에 보자. 어제는 잘 잤다`: {`2022-01-03`, ``}, // (past tense in another sentence)
+		`2021년 1월 3일`:         {`2021-01-03`, ``}, // (not incomplete)
+		`내일`:                  {`2021-12-21`, ``},
+	} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref), WithResolution(ResolveNextOccurrence), WithTenseDetector(DefaultTenseDetector)); err == nil {
+			m := matches[0]
+			until := ""
+			if !m.Until.IsZero() {
+				until = m.Until.Format("2006-01-02")
+			}
+			if m.Date.Format("2006-01-02") != expected.from || until != expected.until {
+				t.Errorf("ExtractDateMatches extracted: %s ~ %s from string: '%s' (expected: %+v)", m.Date.Format("2006-01-02"), until, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// invalid in the reference year: the nearest year in which it exists
+	ref2021 := time.Date(2021, 3, 1, 10, 0, 0, 0, loc)
+	for str, expected := range map[string]string{
+		`2월 29일에 보자`:  `2024-02-29`,
+		`2월 29일에 만났다`: `2020-02-29`,
+	} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref2021), WithResolution(ResolveNextOccurrence), WithStrict(true), WithTenseDetector(DefaultTenseDetector)); err != nil || matches[0].Date.Format("2006-01-02") != expected || matches[0].Normalized {
+			t.Errorf("ExtractDateMatches extracted: %+v from string: '%s' (expected: %s, error: %v)", matches, str, expected, err)
+		}
+	}
+	if date, err := ExtractDate(`31일에 보자`, true, WithReferenceTime(time.Date(2021, 2, 10, 10, 0, 0, 0, loc)), WithResolution(ResolveNextOccurrence)); err != nil || date.Format("2006-01-02") != `2021-03-31` {
+		t.Errorf("ExtractDate extracted: %s (error: %v)", date.Format("2006-01-02"), err)
+	}
+	if matches, err := ExtractDateMatches(`2월 30일에 보자`, true, WithReferenceTime(ref2021), WithResolution(ResolveNextOccurrence)); err != nil || !matches[0].Normalized {
+		t.Errorf("ExtractDateMatches extracted: %+v (error: %v)", matches, err)
+	}
+
+	// without tense detector: past tenses are not detected
+	if date, err := ExtractDate(`1월 3일에 만났다`, true, WithReferenceTime(ref), WithResolution(ResolveNextOccurrence)); err != nil || date.Format("2006-01-02") != `2022-01-03` {
+		t.Errorf("ExtractDate extracted: %s (error: %v)", date.Format("2006-01-02"), err)
+	}
+
+	// default: filled with the reference date's
+	if date, err := ExtractDate(`1월 3일에 만나자`, true, WithReferenceTime(ref)); err != nil || date.Format("2006-01-02") != `2021-01-03` {
+		t.Errorf("ExtractDate extracted: %s (error: %v)", date.Format("2006-01-02"), err)
+	}
+
+	// not filled
+	if date, err := ExtractDate(`1월 3일에 만나자`, false, WithReferenceTime(ref), WithResolution(ResolveNextOccurrence)); err != nil || date.Year() != 0 {
+		t.Errorf("ExtractDate extracted: %s (error: %v)", date.Format("2006-01-02"), err)
+	}
+}
+
+func TestNextOccurrenceTimes(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 12, 20, 10, 0, 0, 0, loc)
+
+	for str, expected := range map[string]string{
+		`오후 3시에 보자`:     `2021-12-20 15:00`,
+		`오전 9시에 보자`:     `2021-12-21 09:00`,
+		`오전 9시에 만났다`:    `2021-12-20 09:00`,
+		`오후 3시에 만났다`:    `2021-12-19 15:00`,
+		`3시간 뒤에 보자`:     `2021-12-20 13:00`,
+		`모레 오전 9시에 보자`:  `2021-12-22 09:00`,
+		`1월 3일 2시에 만났다`: `2021-01-03 02:00`,
+	} {
+		if datetime, err := ExtractDateTime(str, true, WithReferenceTime(ref), WithResolution(ResolveNextOccurrence), WithTenseDetector(DefaultTenseDetector)); err == nil {
+			if datetime.Format("2006-01-02 15:04") != expected {
+				t.Errorf("ExtractDateTime extracted: %s from string: '%s' (expected: %s)", datetime.Format("2006-01-02 15:04"), str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateTime failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// ambiguous times without a policy: the nearest of AM and PM
+	for str, expected := range map[string]string{
+		`3시에 보자`:   `2021-12-20 15:00`,
+		`11시에 보자`:  `2021-12-20 11:00`,
+		`9시에 보자`:   `2021-12-20 21:00`,
+		`3시에 만났다`:  `2021-12-20 03:00`,
+		`11시에 만났다`: `2021-12-19 23:00`,
+	} {
+		if matches, err := ExtractDateTimeMatches(str, true, WithReferenceTime(ref), WithResolution(ResolveNextOccurrence), WithTenseDetector(DefaultTenseDetector)); err == nil {
+			if m := matches[0]; m.DateTime.Format("2006-01-02 15:04") != expected || len(m.Candidates) != 2 || !m.Candidates[0].Equal(m.DateTime) {
+				t.Errorf("ExtractDateTimeMatches extracted: %s (candidates: %v) from string: '%s' (expected: %s)", m.DateTime.Format("2006-01-02 15:04"), m.Candidates, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateTimeMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+}
@@ -0,0 +1,455 @@
+package lkdp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rule for extracting dates
+type dateRule struct {
+	name  string
+	re    *regexp.Regexp
+	group int // submatch used as the span (eg: without boundaries), or 0 for the whole match
+	parse func(e *extraction, slices []string) (match DateMatch, ok bool)
+}
+
+// rule for extracting times
+type timeRule struct {
+	name  string
+	re    *regexp.Regexp
+	reOf  func(p *Parser) *regexp.Regexp // for parser-specific regular expressions (when `re` is nil)
+	group int                            // submatch used as the span (eg: without boundaries), or 0 for the whole match
+	parse func(e *extraction, slices []string) (hms Hms, ok bool)
+}
+
+// RuleSet is an ordered set of rules for extraction
+//
+// rules which come first have higher priorities
+type RuleSet struct {
+	dates       []dateRule
+	times       []timeRule
+	recurrences []recurrenceRule
+}
+
+// DefaultRuleSet returns a rule set with all the built-in rules
+func DefaultRuleSet() RuleSet {
+	return RuleSet{
+		dates: []dateRule{
+			{name: RuleDateRel1, re: dateRelRe1, parse: parseDateRel1},
+			{name: RuleDateRel2, re: dateRelRe2, parse: parseDateRel2},
+			{name: RuleDateWeekday1, re: dateWeekdayRe1, parse: parseDateWeekday1},
+			{name: RuleDateRelMonth1, re: dateRelMonthRe1, parse: parseDateRelMonth1},
+			{name: RuleDateRelWeek1, re: dateRelWeekRe1, parse: parseDateRelWeek1},
+			{name: RuleDateRelYear1, re: dateRelYearRe1, parse: parseDateRelYear1},
+			{name: RuleDateOrdinal1, re: dateOrdinalRe1, parse: parseDateOrdinal1},
+			{name: RuleDateLunar1, re: dateLunarRe1, parse: parseDateLunar1},
+			{name: RuleDateHoliday1, re: dateHolidayRe1, group: 1, parse: parseDateHoliday1},
+			{name: RuleDateBirthYear1, re: dateBirthYearRe1, parse: parseDateBirthYear1},
+			{name: RuleDateExact1, re: dateExactRe1, parse: parseDateExact},
+			{name: RuleDateExact2, re: dateExactRe2, parse: parseDateExact},
+			{name: RuleDatePartial1, re: datePartialRe1, parse: parseDatePartial1},
+		},
+		times: []timeRule{
+			{name: RuleTimeRel1, re: timeRelRe1, parse: parseTimeRel1},
+			{name: RuleTimeExact1, re: timeExactRe1, parse: parseTimeExact1},
+			{name: RuleTimeExact2, re: timeExactRe2, parse: parseTimeExact2},
+			{name: RuleTimeDayPeriod1, reOf: dayPeriodsRe, group: 1, parse: parseTimeDayPeriod1},
+		},
+		recurrences: []recurrenceRule{
+			{name: RuleRecurrenceDaily1, re: recurrenceDailyRe1, parse: parseRecurrenceDaily},
+			{name: RuleRecurrenceWeekdays1, re: recurrenceWeekdaysRe1, parse: parseRecurrenceWeekdays},
+			{name: RuleRecurrenceWeekly1, re: recurrenceWeeklyRe1, parse: parseRecurrenceWeekly},
+			{name: RuleRecurrenceMonthly1, re: recurrenceMonthlyRe1, parse: parseRecurrenceMonthly},
+			{name: RuleRecurrenceYearly1, re: recurrenceYearlyRe1, parse: parseRecurrenceYearly},
+		},
+	}
+}
+
+// Names returns the names of rules in this rule set (in the order of priority)
+func (rs RuleSet) Names() (names []string) {
+	for _, r := range rs.dates {
+		names = append(names, r.name)
+	}
+	for _, r := range rs.times {
+		names = append(names, r.name)
+	}
+	for _, r := range rs.recurrences {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+// Without returns a copy of this rule set without the rules with given names
+//
+// eg: DefaultRuleSet().Without(RuleDateExact2, RuleTimeRel1)
+func (rs RuleSet) Without(names ...string) RuleSet {
+	excluded := map[string]struct{}{}
+	for _, name := range names {
+		excluded[name] = struct{}{}
+	}
+
+	filtered := RuleSet{}
+	for _, r := range rs.dates {
+		if _, exists := excluded[r.name]; !exists {
+			filtered.dates = append(filtered.dates, r)
+		}
+	}
+	for _, r := range rs.times {
+		if _, exists := excluded[r.name]; !exists {
+			filtered.times = append(filtered.times, r)
+		}
+	}
+	for _, r := range rs.recurrences {
+		if _, exists := excluded[r.name]; !exists {
+			filtered.recurrences = append(filtered.recurrences, r)
+		}
+	}
+	return filtered
+}
+
+// '3일 후', '2개월 전', '1년 2개월 3일 후', '2주 뒤' 등
+func parseDateRel1(e *extraction, slices []string) (DateMatch, bool) {
+	duration := parseDurationUnits(slices[1], slices[2] != "")
+
+	multiply := 1
+	switch slices[3] {
+	case ExpressionBefore1: // before
+		multiply = -1
+	case ExpressionAfter1, ExpressionAfter2: // after
+		// do nothing (+1)
+	}
+
+	date := e.now.AddDate(multiply*duration.Years, multiply*duration.Months, multiply*duration.Days)
+
+	return DateMatch{Date: time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, e.location)}, true
+}
+
+// '내년', '어제', '모레' 등
+func parseDateRel2(e *extraction, slices []string) (DateMatch, bool) {
+	date := e.now
+
+	switch slices[0] {
+	case ExpressionYearBefore: // 1 year before
+		date = date.AddDate(-1, 0, 0)
+	case ExpressionYearBeforeLast: // 2 years before
+		date = date.AddDate(-2, 0, 0)
+	case ExpressionYearNext: // 1 year after
+		date = date.AddDate(1, 0, 0)
+	case ExpressionYearAfterNext: // 2 years after
+		date = date.AddDate(2, 0, 0)
+	case ExpressionTheDayBeforeYesterday1, ExpressionTheDayBeforeYesterday2: // 2 days before
+		date = date.AddDate(0, 0, -2)
+	case ExpressionYesterday1, ExpressionYesterday2: // 1 day before
+		date = date.AddDate(0, 0, -1)
+	case ExpressionToday1, ExpressionToday2: // today
+		// do nothing (= today)
+	case ExpressionTomorrow1, ExpressionTomorrow2: // 1 day after
+		date = date.AddDate(0, 0, 1)
+	case ExpressionTheDayAfterTomorrow1: // 2 days after
+		date = date.AddDate(0, 0, 2)
+	case ExpressionTwoDaysAfterTomorrow1: // 3 days after
+		date = date.AddDate(0, 0, 3)
+	default:
+		// do nothing
+	}
+
+	return DateMatch{Date: time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, e.location)}, true
+}
+
+// '월요일', '다음 주 금요일', '지난주 수요일', '金曜日' 등
+//
+// 주 표현 없이 요일만 주어진 경우 오늘을 포함하여 다가오는 요일로 계산
+func parseDateWeekday1(e *extraction, slices []string) (DateMatch, bool) {
+	weekday, ok := weekdayOf(slices[3])
+	if !ok {
+		return DateMatch{}, false
+	}
+
+	today := time.Date(e.now.Year(), e.now.Month(), e.now.Day(), 0, 0, 0, 0, e.location)
+
+	var weeks int
+	switch slices[2] {
+	case "": // upcoming weekday (including today)
+		return DateMatch{Date: today.AddDate(0, 0, (int(weekday)-int(today.Weekday())+7)%7)}, true
+	case ExpressionWeekThis1, ExpressionWeekThis2: // this week
+		weeks = 0
+	case ExpressionWeekNext1, ExpressionWeekNext2, ExpressionWeekNext3: // next week
+		weeks = 1
+	case ExpressionWeekAfterNext1: // the week after next
+		weeks = 2
+	case ExpressionWeekBefore1, ExpressionWeekBefore2: // last week
+		weeks = -1
+	case ExpressionWeekBeforeLast1: // the week before last
+		weeks = -2
+	}
+
+	return DateMatch{Date: startOfWeek(today, e.weekStart).AddDate(0, 0, weeks*7+(int(weekday)-int(e.weekStart)+7)%7)}, true
+}
+
+// returns the weekday of given expression (eg: time.Friday for '금', '金')
+func weekdayOf(str string) (time.Weekday, bool) {
+	switch str {
+	case ExpressionMonday1, ExpressionMonday2:
+		return time.Monday, true
+	case ExpressionTuesday1, ExpressionTuesday2:
+		return time.Tuesday, true
+	case ExpressionWednesday1, ExpressionWednesday2:
+		return time.Wednesday, true
+	case ExpressionThursday1, ExpressionThursday2:
+		return time.Thursday, true
+	case ExpressionFriday1, ExpressionFriday2:
+		return time.Friday, true
+	case ExpressionSaturday1, ExpressionSaturday2:
+		return time.Saturday, true
+	case ExpressionSunday1, ExpressionSunday2:
+		return time.Sunday, true
+	}
+	return time.Sunday, false
+}
+
+// returns the first day of the week (starting from `weekStart`) which contains given date
+func startOfWeek(date time.Time, weekStart time.Weekday) time.Time {
+	return date.AddDate(0, 0, -((int(date.Weekday()) - int(weekStart) + 7) % 7))
+}
+
+// '음력 8월 15일', '음력 2020년 윤4월 8일' 등
+//
+// 연도가 없는 경우 (`ifEmptyFillAsToday`와 관계 없이) 기준 시간의 음력 연도로 계산하되,
+// 그 해에 없는 날짜('음력 윤4월 8일')는 그 날짜가 있는 가장 가까운 연도로 계산
+func parseDateLunar1(e *extraction, slices []string) (DateMatch, bool) {
+	year, _ := e.year(slices[3])
+	month64, _ := strconv.ParseInt(slices[5], 10, 16)
+	day64, _ := strconv.ParseInt(slices[6], 10, 16)
+
+	lunar := LunarDate{Year: year, Month: int(month64), Day: int(day64), Leap: slices[4] != ""}
+	if lunar.Year <= 0 {
+		today, err := SolarToLunar(e.now)
+		if err != nil {
+			return DateMatch{}, false
+		}
+		lunar.Year = today.Year
+
+		if nearest, exists := nearestLunarYear(lunar, e.location); exists {
+			lunar.Year = nearest
+		}
+	}
+
+	date, err := lunar.Solar(e.location)
+	if err != nil {
+		e.debugPrint("%s: failed to convert lunar date: %s", RuleDateLunar1, err)
+		return DateMatch{invalid: asError(err, ErrInvalidDate)}, true // (invalid without a date: always rejected)
+	}
+
+	return DateMatch{Date: date}, true
+}
+
+// returns the nearest lunar year (from given date's year) in which given lunar date exists
+// (eg: 2020 for '윤4월 8일' in 2021), preferring the later one for the same distance
+func nearestLunarYear(lunar LunarDate, location *time.Location) (year int, exists bool) {
+	for n := 0; n <= LunarYearMax-LunarYearMin; n++ {
+		for _, y := range []int{lunar.Year + n, lunar.Year - n} {
+			if y < LunarYearMin || y > LunarYearMax {
+				continue
+			}
+			if _, err := (LunarDate{Year: y, Month: lunar.Month, Day: lunar.Day, Leap: lunar.Leap}).Solar(location); err == nil {
+				return y, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// '추석', '올해 설날', '지난 크리스마스', '2020년 추석 연휴' 등
+//
+// 연도가 없는 경우 오늘을 포함하여 다가오는 날짜로 ('지난', '저번'의 경우 지나간 날짜로) 계산,
+// '연휴'가 붙은 경우 연휴의 첫날부터 마지막 날까지의 기간으로 계산
+func parseDateHoliday1(e *extraction, slices []string) (DateMatch, bool) {
+	if slices[2] != "" || slices[8] != "" { // in a word (eg: '구정물', '신정동')
+		return DateMatch{}, false
+	}
+	holiday, exists := LookupHoliday(slices[5])
+	if !exists {
+		return DateMatch{}, false
+	}
+	withHolidays := slices[7] != ""
+	if !withHolidays {
+		for _, name := range holidayNamesOnlyWithHolidays {
+			if slices[5] == name {
+				return DateMatch{}, false
+			}
+		}
+	}
+
+	// returns the period of the holiday in given year
+	period := func(year int) (from, to time.Time, err error) {
+		if withHolidays {
+			return holiday.Period(year, e.location)
+		}
+		from, err = holiday.Date(year, e.location)
+		return from, from, err
+	}
+
+	today := time.Date(e.now.Year(), e.now.Month(), e.now.Day(), 0, 0, 0, 0, e.location)
+	year := today.Year()
+
+	var from, to time.Time
+	var err error
+	switch slices[3] {
+	case ExpressionYearThis1, ExpressionYearThis2, ExpressionYearThis3:
+		from, to, err = period(year)
+	case ExpressionYearNext:
+		from, to, err = period(year + 1)
+	case ExpressionYearAfterNext:
+		from, to, err = period(year + 2)
+	case ExpressionYearBefore, ExpressionYearBefore2:
+		from, to, err = period(year - 1)
+	case ExpressionYearBeforeLast, ExpressionYearBeforeLast2:
+		from, to, err = period(year - 2)
+	case ExpressionPrevious1, ExpressionPrevious2: // the last one (before today)
+		if from, to, err = period(year); err == nil && !from.Before(today) {
+			from, to, err = period(year - 1)
+		}
+	case "", ExpressionThis1, ExpressionNext1, ExpressionUpcoming1, ExpressionUpcoming2: // the upcoming one (including today)
+		if from, to, err = period(year); err == nil && to.Before(today) {
+			from, to, err = period(year + 1)
+		}
+	default: // with year (eg: '2020년')
+		year, _ := e.year(slices[4])
+		from, to, err = period(year)
+	}
+	if err != nil {
+		e.debugPrint("%s: failed to calculate the date of %s: %s", RuleDateHoliday1, holiday.Name, err)
+		return DateMatch{}, false
+	}
+
+	if withHolidays {
+		return DateMatch{Date: from, Until: to}, true
+	}
+	return DateMatch{Date: from}, true
+}
+
+// '2020년 5월 18일', '2020.05.18' 등 (dateExactRe1, dateExactRe2)
+func parseDateExact(e *extraction, slices []string) (DateMatch, bool) {
+	var values [3]int
+	for i, slice := range []string{slices[2], slices[4], slices[5]} {
+		if slice == "" {
+			continue
+		}
+		var value int
+		var ok bool
+		if i == 0 {
+			value, ok = e.year(slice) // (two-digit years are resolved with the century window)
+		} else {
+			value64, err := strconv.ParseInt(slice, 10, 16)
+			value, ok = int(value64), err == nil
+		}
+		if !ok { // (invalid without a date: rejected in strict mode, ignored otherwise)
+			return DateMatch{invalid: newError(ErrOutOfRange, "%s은(는) 범위를 벗어났습니다", slice)}, true
+		}
+		values[i] = value
+	}
+	year, month, day := values[0], values[1], values[2]
+
+	var missing dateParts
+	if slices[2] == "" {
+		missing |= dateYear
+	}
+	if slices[4] == "" {
+		missing |= dateMonth
+	}
+
+	if e.fill {
+		if missing&dateYear != 0 {
+			year = e.now.Year()
+		}
+		if missing&dateMonth != 0 {
+			month = int(e.now.Month())
+		}
+	}
+
+	invalid := validateDate(year, month, day)
+	if month == 0 && missing&dateMonth == 0 { // (explicit zero month, eg: '0월 5일')
+		invalid = newError(ErrInvalidDate, "%d월은 존재하지 않습니다", month)
+	}
+
+	return DateMatch{Date: time.Date(year, time.Month(month), day, 0, 0, 0, 0, e.location), missing: missing, invalid: invalid, month: month, day: day}, true
+}
+
+// '1시간 전', '5분 뒤', '3시간 10분 뒤', '1일 3시간 후', '한 시간 반 뒤' 등
+func parseTimeRel1(e *extraction, slices []string) (Hms, bool) {
+	duration := parseDurationUnits(slices[1], slices[2] != "")
+	if duration.Time == 0 { // without time units (eg: '3일 후')
+		return Hms{}, false
+	}
+
+	multiply := 1
+	switch slices[3] {
+	case ExpressionBefore1: // before
+		multiply = -1
+	case ExpressionAfter1, ExpressionAfter2: // after
+		// do nothing (+1)
+	}
+
+	when := e.now.AddDate(multiply*duration.Years, multiply*duration.Months, multiply*duration.Days).Add(time.Duration(multiply) * duration.Time)
+
+	return Hms{Hours: when.Hour(), Minutes: when.Minute(), Seconds: when.Second(), NumDaysChanged: daysBetween(e.now, when), Ambiguous: false}, true
+}
+
+// 'xx시 반'
+func parseTimeExact1(e *extraction, slices []string) (Hms, bool) {
+	var hour64 int64
+	var err error
+	if hour64, err = strconv.ParseInt(slices[3], 10, 16); err != nil && e.fill {
+		hour64 = int64(e.now.Hour())
+	}
+
+	hour, ambiguous := applyAmPm(slices[1], int(hour64))
+
+	return Hms{Hours: hour, Minutes: 30, Seconds: 0, NumDaysChanged: 0, Ambiguous: ambiguous}, true
+}
+
+// 'xx시 xx분 xx초', 'xx:xx:xx' 등
+func parseTimeExact2(e *extraction, slices []string) (Hms, bool) {
+	var hour64, minute64, second64 int64 = 0, 0, 0
+	var err error
+	if hour64, err = strconv.ParseInt(slices[3], 10, 16); err != nil && e.fill {
+		hour64 = int64(e.now.Hour())
+	}
+	if minute64, err = strconv.ParseInt(slices[5], 10, 16); err != nil && e.fill {
+		minute64 = int64(e.now.Minute())
+	}
+	seconds := slices[7] // 'hh:mm:ss'
+	if seconds == "" {
+		seconds = slices[8] // 'hh시 mm분 ss초'
+	}
+	if second64, err = strconv.ParseInt(seconds, 10, 16); err != nil && e.fill {
+		second64 = int64(e.now.Second())
+	}
+
+	hour, ambiguous := applyAmPm(slices[1], int(hour64))
+
+	return Hms{Hours: hour, Minutes: int(minute64), Seconds: int(second64), NumDaysChanged: 0, Ambiguous: ambiguous}, true
+}
+
+// 오전/오후(AM/PM) 구분에 따라 시간값을 변환하고, 모호한 시간인지 여부를 함께 반환
+//
+// ('오후 12시' = 12시, '오전 12시' = 0시)
+func applyAmPm(ampm string, hour int) (int, bool) {
+	ambiguous := false
+	if strings.EqualFold(ampm, ExpressionPeriodPM1) || strings.EqualFold(ampm, ExpressionPeriodPM2) {
+		if hour < 12 {
+			hour += 12
+		}
+	} else if strings.EqualFold(ampm, ExpressionPeriodAM1) || strings.EqualFold(ampm, ExpressionPeriodAM2) {
+		if hour == 12 {
+			hour = 0
+		}
+	} else {
+		if hour < 12 {
+			ambiguous = true
+		}
+	}
+	return hour, ambiguous
+}
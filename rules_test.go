@@ -0,0 +1,100 @@
+package lkdp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWeekdays(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2020, 11, 11, 9, 0, 0, 0, loc) // Wednesday
+
+	for str, expected := range map[string]string{
+		`월요일에 보자`:      `2020-11-16`,
+		`수요일`:          `2020-11-11`,
+		`이번 주 토요일`:     `2020-11-14`,
+		`이번주 월요일`:      `2020-11-09`,
+		`다음 주 금요일 회의`:  `2020-11-20`,
+		`다다음주 월요일`:     `2020-11-23`,
+		`지난주 수요일에 만났다`: `2020-11-04`,
+		`저번 주 일요일`:     `2020-11-08`,
+		`차주 화요일`:       `2020-11-17`,
+		`金曜日`:          `2020-11-13`,
+		`이번 주 일요일`:     `2020-11-15`,
+	} {
+		if d, err := ExtractDate(str, true, WithReferenceTime(ref)); err == nil {
+			if d.Format("2006-01-02") != expected {
+				t.Errorf("ExtractDate extracted date: %s from string: '%s' (expected: %s)", d.Format("2006-01-02"), str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDate failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// weeks starting from sunday
+	p := NewParser(WithReferenceTime(ref), WithWeekStart(time.Sunday))
+	for str, expected := range map[string]string{
+		`이번 주 일요일`: `2020-11-08`,
+		`다음 주 일요일`: `2020-11-15`,
+		`이번 주 토요일`: `2020-11-14`,
+	} {
+		if d, err := p.ExtractDate(str, true); err == nil {
+			if d.Format("2006-01-02") != expected {
+				t.Errorf("ExtractDate extracted date: %s from string: '%s' (expected: %s)", d.Format("2006-01-02"), str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDate failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+}
+
+func TestExactTimes(t *testing.T) {
+	for str, expected := range map[string]string{
+		`5시 01분`:       `05:01:00`,
+		`5시 01분 30초`:   `05:01:30`,
+		`PM 03:30:15`:  `15:30:15`,
+		`오후 3시 5분에 보자`: `15:05:00`,
+	} {
+		if matches, err := ExtractTimeMatches(str, false); err == nil {
+			m := matches[0]
+			if hms := fmt.Sprintf("%02d:%02d:%02d", m.Hms.Hours, m.Hms.Minutes, m.Hms.Seconds); hms != expected || !strings.HasPrefix(str, m.Text) || strings.ContainsAny(str[m.End:], "분초") {
+				t.Errorf("ExtractTimeMatches extracted time: %s (%s) from string: '%s' (expected: %s)", hms, m.Text, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractTimeMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+}
+
+func TestCompoundRelatives(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 22, 30, 0, 0, loc)
+
+	for str, expected := range map[string]string{
+		`1년 2개월 3일 후`:  `2022-05-04 00:00`,
+		`1년 2개월 후에 봐요`: `2022-05-01 00:00`,
+		`2주 뒤`:         `2021-03-15 00:00`,
+		`3시간 10분 뒤`:    `2021-03-02 01:40`,
+		`1시간 30분 전`:    `2021-03-01 21:00`,
+		`한 시간 반 뒤`:     `2021-03-02 00:00`,
+		`1일 3시간 후`:     `2021-03-03 01:30`,
+		`1년 반 전`:       `2019-09-01 00:00`,
+	} {
+		if matches, err := ExtractDateTimeMatches(str, true, WithReferenceTime(ref)); err == nil {
+			if len(matches) != 1 || matches[0].DateTime.Format("2006-01-02 15:04") != expected {
+				t.Errorf("ExtractDateTimeMatches extracted: %s (%d matches) from string: '%s' (expected: %s)", matches[0].DateTime.Format("2006-01-02 15:04"), len(matches), str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateTimeMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// no dates in relative times (eg: '3일' of '3일 10시간 후')
+	for _, str := range []string{`3일 10시간 후`, `1일 3시간 후에 보자`} {
+		if dates, err := ExtractDates(str, true, WithReferenceTime(ref)); err == nil {
+			t.Errorf("ExtractDates should fail with string: '%s' (extracted: %v)", str, dates)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package lkdp
+
+// 시제 추론
+//
+// eg: '3시에 만났다' => 과거, '3시에 만나자', '3시에 만날 예정' => 미래
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Tense is the tense inferred from the text around an expression
+type Tense int
+
+// tenses
+const (
+	TenseUnknown Tense = iota
+	TensePast
+	TenseFuture
+)
+
+// String returns the name of this tense
+func (t Tense) String() string {
+	switch t {
+	case TensePast:
+		return "past"
+	case TenseFuture:
+		return "future"
+	}
+	return "unknown"
+}
+
+// TenseDetector infers the tense of an expression from the text around it
+type TenseDetector interface {
+	// Tense returns the tense inferred from the text before and after an expression (in the same sentence)
+	Tense(before, after string) Tense
+}
+
+// TenseDetectorFunc is a function which implements TenseDetector interface
+type TenseDetectorFunc func(before, after string) Tense
+
+// Tense returns the inferred tense
+func (f TenseDetectorFunc) Tense(before, after string) Tense {
+	return f(before, after)
+}
+
+// DefaultTenseDetector infers the tense from korean verb endings (the last one after the expression, or before it)
+//
+// (not used by default, set it with WithTenseDetector)
+//
+// past: '었', '았', '했', '였', ... (except '있', '겠')
+//
+// future: 'ㄹ 것', 'ㄹ 거', 'ㄹ게', 'ㄹ까', '겠', '예정', '계획', '기로 했', '려고', '하자', '합시다'
+var DefaultTenseDetector TenseDetector = TenseDetectorFunc(func(before, after string) Tense {
+	if tense := lastTense(after); tense != TenseUnknown {
+		return tense
+	}
+	return lastTense(before)
+})
+
+var tensePastRe, tenseFutureRe *regexp.Regexp
+
+// end of a sentence (eg: '. ', '!', '?', new line)
+var sentenceEndRe = regexp.MustCompile(`[.!?。]+(?:\s+|$)|\n`)
+
+func init() {
+	// syllables which end with given final consonant (eg: 'ㅆ' = 20, 'ㄹ' = 8)
+	syllables := func(final rune, except ...rune) string {
+		var builder strings.Builder
+		for r := '가'; r <= '힣'; r++ {
+			if (r-'가')%28 == final && !strings.ContainsRune(string(except), r) {
+				builder.WriteRune(r)
+			}
+		}
+		return builder.String()
+	}
+
+	tensePastRe = regexp.MustCompile(fmt.Sprintf(`[%s]`, syllables(20, '있', '겠')))
+	// (not a date like '5일까지', '3월까지')
+	tenseFutureRe = regexp.MustCompile(fmt.Sprintf(`(?:^|[^\d])[%s]\s*(?:것|거|게|래|까요?(?:[\s.!?~]|$))|겠|예정|계획|기로\s*(?:했|하였|됐|되었)|려고|(?:자|시다)(?:고|요)?[\s.!?~]*$`, syllables(8)))
+}
+
+// returns the tense of the last marker in given text
+func lastTense(text string) Tense {
+	pastEnd, futureEnd := -1, -1
+	if indices := tensePastRe.FindAllStringIndex(text, -1); len(indices) > 0 {
+		pastEnd = indices[len(indices)-1][1]
+	}
+	if indices := tenseFutureRe.FindAllStringIndex(text, -1); len(indices) > 0 {
+		futureEnd = indices[len(indices)-1][1]
+	}
+
+	switch {
+	case futureEnd >= 0 && futureEnd >= pastEnd: // (eg: '만나기로 했다')
+		return TenseFuture
+	case pastEnd >= 0:
+		return TensePast
+	}
+	return TenseUnknown
+}
+
+// returns the text before and after given span, in the same sentence
+func (e *extraction) sentenceAround(span Span) (before, after string) {
+	before, after = e.str[:span.Start], e.str[span.End:]
+	if indices := sentenceEndRe.FindAllStringIndex(before, -1); len(indices) > 0 {
+		before = before[indices[len(indices)-1][1]:]
+	}
+	if loc := sentenceEndRe.FindStringIndex(after); loc != nil {
+		after = after[:loc[0]]
+	}
+	return before, after
+}
+
+// returns the tense of given span with the tense detector (TenseUnknown if there is no detector)
+func (e *extraction) tense(span Span) Tense {
+	if e.tenseDetector == nil {
+		return TenseUnknown
+	}
+	return e.tenseDetector.Tense(e.sentenceAround(span))
+}
@@ -0,0 +1,87 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectTenses(t *testing.T) {
+	for str, expected := range map[string]Tense{
+		`3시에 만났다`:           TensePast,
+		`3시에 만나자`:           TenseFuture,
+		`3시에 만날 예정입니다`:      TenseFuture,
+		`3시에 갈 거야`:          TenseFuture,
+		`3시에 도착했었어`:         TensePast,
+		`3시에 보기로 했다`:        TenseFuture,
+		`3시에 회의가 있다`:        TenseUnknown,
+		`3시에 하겠습니다`:         TenseFuture,
+		`3시`:                TenseUnknown,
+		`어제 만났던 곳에서 3시에 보자`: TenseFuture,
+		`어제 갔었는데, 3시에`:      TensePast, // (before the expression)
+		`3시에 보자. 어제는 잘 잤다`:  TenseFuture,
+		`어제는 잘 잤다. 3시에 봅시다`: TenseFuture,
+	} {
+		if matches, err := ExtractTimeMatches(str, false, WithTenseDetector(DefaultTenseDetector)); err == nil {
+			if matches[0].Tense != expected {
+				t.Errorf("ExtractTimeMatches inferred tense: %s from string: '%s' (expected: %s)", matches[0].Tense, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractTimeMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// dates
+	for str, expected := range map[string]Tense{
+		`1월 3일부터 5일까지 휴가`:  TenseUnknown,
+		`3월까지 끝냈다`:         TensePast,
+		`1월 3일부터 5일까지 쉬었다`: TensePast,
+		`3월에 갈까?`:          TenseFuture,
+	} {
+		if matches, err := ExtractDateMatches(str, false, WithTenseDetector(DefaultTenseDetector)); err == nil {
+			if matches[0].Tense != expected {
+				t.Errorf("ExtractDateMatches inferred tense: %s from string: '%s' (expected: %s)", matches[0].Tense, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// without detector (default)
+	if matches, err := ExtractTimeMatches(`3시에 만났다`, false); err != nil || matches[0].Tense != TenseUnknown {
+		t.Errorf("ExtractTimeMatches should not infer tense without detector: %+v (error: %v)", matches, err)
+	}
+
+	// custom detector
+	detector := TenseDetectorFunc(func(before, after string) Tense {
+		return TensePast
+	})
+	if matches, err := ExtractDateMatches(`1월 3일에 만나자`, true, WithTenseDetector(detector)); err != nil || matches[0].Tense != TensePast {
+		t.Errorf("ExtractDateMatches should infer tense with custom detector: %+v (error: %v)", matches, err)
+	}
+}
+
+func TestTenseAwareResolutions(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 10, 0, 0, 0, loc)
+
+	// ambiguous times with PreferFuture
+	for str, expected := range map[string]string{
+		`3시에 만나자`:    `2021-03-01 15:00`,
+		`3시에 만났다`:    `2021-03-01 03:00`,
+		`어제 9시에 만났다`: `2021-02-28 21:00`,
+		`11시에 봤어`:    `2021-02-28 23:00`,
+	} {
+		if matches, err := ExtractDateTimeMatches(str, true, WithReferenceTime(ref), WithAmbiguityPolicy(PreferFuture), WithResolution(ResolveNextOccurrence), WithTenseDetector(DefaultTenseDetector)); err == nil {
+			if m := matches[0]; m.DateTime.Format("2006-01-02 15:04") != expected {
+				t.Errorf("ExtractDateTimeMatches extracted: %s (tense: %s) from string: '%s' (expected: %s)", m.DateTime.Format("2006-01-02 15:04"), m.Tense, str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateTimeMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// tenses are not used by other policies
+	if hms, err := ExtractTime(`3시에 만났다`, false, WithReferenceTime(ref), WithAmbiguityPolicy(PreferBusinessHours)); err != nil || hms.Hours != 15 {
+		t.Errorf("ExtractTime extracted: %+v (error: %v)", hms, err)
+	}
+}
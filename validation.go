@@ -0,0 +1,99 @@
+package lkdp
+
+// 날짜/시간 값의 검증
+//
+// eg: '2월 30일', '13월 5일', '25시 70분'
+//
+// strict 모드에서는 에러(*Error)로 거부하고, 기본(lenient) 모드에서는 정규화한 뒤 `Normalized`로 표시
+
+import (
+	"time"
+)
+
+// reject an invalid date/time (in strict mode)
+func (e *extraction) reject(subject Subject, span Span, invalid *Error) {
+	e.debugPrint("rejected '%s': %s", span.Text, invalid.Reason)
+
+	e.rejected = append(e.rejected, e.error(invalid.Kind, subject, span, invalid.Reason))
+}
+
+// returns the left-most (and the longest) rejected date/time as an error, or nil if nothing was rejected
+func (e *extraction) rejection() error {
+	var first *Error
+	for _, rejected := range e.rejected {
+		if first == nil || rejected.Start < first.Start || (rejected.Start == first.Start && rejected.End > first.End) {
+			first = rejected
+		}
+	}
+	if first == nil {
+		return nil
+	}
+	return first
+}
+
+// returns an error (ErrInvalidDate) if given date is invalid, or nil if it is valid
+//
+// (zero year/month are not given ones, and are not validated)
+func validateDate(year, month, day int) *Error {
+	if month < 0 || month > 12 {
+		return newError(ErrInvalidDate, "%d월은 존재하지 않습니다", month)
+	}
+	if month == 0 {
+		if day < 1 || day > 31 {
+			return newError(ErrInvalidDate, "%d일은 존재하지 않습니다", day)
+		}
+		return nil
+	}
+	if day < 1 || day > daysIn(year, time.Month(month)) {
+		if year > 0 {
+			return newError(ErrInvalidDate, "%d년 %d월 %d일은 존재하지 않습니다", year, month, day)
+		}
+		return newError(ErrInvalidDate, "%d월 %d일은 존재하지 않습니다", month, day)
+	}
+	return nil
+}
+
+// number of days in given month (for year 0, as a leap year)
+func daysIn(year int, month time.Month) int {
+	if year <= 0 {
+		year = 2000
+	}
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// returns an error (ErrOutOfRange) if this time is out of range, or nil if it is valid
+//
+// ('24시' is out of range, and is normalized to the midnight of the next day)
+func (h Hms) invalid() *Error {
+	switch {
+	case h.Hours < 0 || h.Hours > 23:
+		return newError(ErrOutOfRange, "%d시는 범위를 벗어났습니다", h.Hours)
+	case h.Minutes < 0 || h.Minutes > 59:
+		return newError(ErrOutOfRange, "%d분은 범위를 벗어났습니다", h.Minutes)
+	case h.Seconds < 0 || h.Seconds > 59:
+		return newError(ErrOutOfRange, "%d초는 범위를 벗어났습니다", h.Seconds)
+	}
+	return nil
+}
+
+// returns this time with out-of-range values carried over (eg: 25:70 => 02:10 of the next day)
+func (h Hms) normalized() Hms {
+	seconds := h.Hours*3600 + h.Minutes*60 + h.Seconds
+	h.Hours, h.Minutes, h.Seconds = seconds/3600%24, seconds/60%60, seconds%60
+	h.NumDaysChanged += seconds / (24 * 3600)
+	return h
+}
+
+// returns an error (ErrInvalidDate) if dates of this recurrence never exist, or nil if it is valid
+//
+// (eg: '매년 2월 30일', but '매년 2월 29일' is valid for leap years)
+func (r Recurrence) invalid() *Error {
+	for _, month := range r.ByMonth {
+		for _, day := range r.ByMonthDay {
+			if invalid := validateDate(0, month, day); invalid != nil {
+				return invalid
+			}
+		}
+	}
+	return nil
+}
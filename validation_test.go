@@ -0,0 +1,87 @@
+package lkdp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLenientValidation(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 2, 1, 9, 0, 0, 0, loc)
+
+	// normalized dates
+	for str, expected := range map[string]string{
+		`2월 30일`:       `2021-03-02`,
+		`2021년 13월 5일`: `2022-01-05`,
+		`2021.02.29`:   `2021-03-01`,
+		`31일`:          `2021-03-03`,
+		`0월 5일`:        `2020-12-05`,
+	} {
+		if matches, err := ExtractDateMatches(str, true, WithReferenceTime(ref)); err == nil {
+			if matches[0].Date.Format("2006-01-02") != expected || !matches[0].Normalized {
+				t.Errorf("ExtractDateMatches extracted: %+v from string: '%s' (expected: %s, normalized)", matches[0], str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDateMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+	if matches, err := ExtractDateMatches(`2020년 2월 29일`, true, WithReferenceTime(ref)); err != nil || matches[0].Normalized {
+		t.Errorf("ExtractDateMatches returned: %+v (error: %v)", matches, err)
+	}
+
+	// normalized times
+	if matches, err := ExtractTimeMatches(`25시 70분`, false, WithReferenceTime(ref)); err == nil {
+		if hms := matches[0].Hms; hms.Hours != 2 || hms.Minutes != 10 || hms.NumDaysChanged != 1 || !matches[0].Normalized {
+			t.Errorf("ExtractTimeMatches extracted: %+v from string: '25시 70분'", matches[0])
+		}
+	} else {
+		t.Errorf("ExtractTimeMatches failed with string: '25시 70분' (error: %s)", err)
+	}
+	if dt, err := ExtractDateTime(`내일 25시`, true, WithReferenceTime(ref)); err != nil || dt.Format("2006-01-02 15:04") != `2021-02-03 01:00` {
+		t.Errorf("ExtractDateTime returned: %s (error: %v)", dt, err)
+	}
+	if matches, err := ExtractTimeMatches(`24시`, false, WithReferenceTime(ref)); err == nil {
+		if hms := matches[0].Hms; hms.Hours != 0 || hms.NumDaysChanged != 1 || !matches[0].Normalized {
+			t.Errorf("ExtractTimeMatches extracted: %+v from string: '24시'", matches[0])
+		}
+	} else {
+		t.Errorf("ExtractTimeMatches failed with string: '24시' (error: %s)", err)
+	}
+	if matches, err := ExtractTimeMatches(`오후 12시`, false, WithReferenceTime(ref)); err != nil || matches[0].Hms.Hours != 12 || matches[0].Normalized {
+		t.Errorf("ExtractTimeMatches returned: %+v (error: %v)", matches, err)
+	}
+}
+
+func TestStrictValidation(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 2, 1, 9, 0, 0, 0, loc)
+
+	for str, expected := range map[string]string{
+		`2월 30일에 만나`:      `2월 30일`,
+		`2021년 13월 5일`:    `2021년 13월 5일`,
+		`3월 1일과 2월 29일`:   `2월 29일`,
+		`오늘 25시 70분`:      `25시 70분`,
+		`3시 61분`:          `3시 61분`,
+		`내일 24시`:          `24시`,
+		`오후 24시`:          `오후 24시`,
+		`음력 2021년 4월 30일`: `음력 2021년 4월 30일`,
+		`0월 5일에 보자`:       `0월 5일`,
+	} {
+		_, err := ExtractDateTimeMatches(str, true, WithReferenceTime(ref), WithStrict(true))
+
+		var invalid *Error
+		if !errors.As(err, &invalid) {
+			t.Errorf("ExtractDateTimeMatches should fail with a validation error for string: '%s' (error: %v)", str, err)
+		} else if invalid.Text != expected || str[invalid.Start:invalid.End] != expected {
+			t.Errorf("ExtractDateTimeMatches rejected: '%s' from string: '%s' (expected: '%s')", invalid.Text, str, expected)
+		}
+	}
+
+	// valid ones
+	for _, str := range []string{`2020년 2월 29일`, `12월 31일 23시 59분 59초`, `내일 오후 12시`, `오전 12시 30분`} {
+		if _, err := ExtractDateTimeMatches(str, true, WithReferenceTime(ref), WithStrict(true)); err != nil {
+			t.Errorf("ExtractDateTimeMatches failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package lkdp
+
+// 두 자리 연도
+//
+// eg: '21년 3월 1일', '21.3.1', ''21년', '81년생'
+
+import (
+	"strconv"
+	"time"
+)
+
+// DefaultCenturyWindow is the default number of years after the reference year, for resolving two-digit years
+//
+// (eg: in 2021, '41년' => 2041, '42년' => 1942)
+const DefaultCenturyWindow = 20
+
+// returns the full year of given year string (two-digit years are resolved with the century window)
+//
+// returns false if it is out of range
+func (e *extraction) year(str string) (int, bool) {
+	year64, err := strconv.ParseInt(str, 10, 16)
+	if err != nil {
+		return 0, false
+	}
+	year := int(year64)
+
+	if len(str) == 2 {
+		year = resolveTwoDigitYear(year, e.now.Year(), e.centuryWindow)
+	}
+	return year, true
+}
+
+// resolve given two-digit year in the 100 years which end at `window` years after the reference year
+//
+// eg: resolveTwoDigitYear(81, 2021, 20) => 1981, resolveTwoDigitYear(41, 2021, 20) => 2041
+func resolveTwoDigitYear(year, reference, window int) int {
+	last := reference + window
+	year += last - last%100
+	if year > last {
+		year -= 100
+	}
+	return year
+}
+
+// 'xx년생', '1981년생' (with an optional apostrophe, eg: ‘81년생)
+//
+// (always a year in the past, not after the reference year)
+func parseDateBirthYear1(e *extraction, slices []string) (DateMatch, bool) {
+	year, ok := e.year(slices[1])
+	if !ok {
+		return DateMatch{invalid: newError(ErrOutOfRange, "%s년은 범위를 벗어났습니다", slices[1])}, true
+	}
+	if len(slices[1]) == 2 && year > e.now.Year() {
+		year -= 100
+	}
+
+	from, to := GranularityYear.period(time.Date(year, 1, 1, 0, 0, 0, 0, e.location), e.weekStart)
+
+	return DateMatch{Date: from, Until: to, Granularity: GranularityYear, missing: dateMonth | dateDay}, true
+}
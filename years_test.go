@@ -0,0 +1,55 @@
+package lkdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTwoDigitYears(t *testing.T) {
+	loc, _ := time.LoadLocation(DefaultLocation)
+	ref := time.Date(2021, 3, 1, 9, 0, 0, 0, loc)
+
+	for str, expected := range map[string]string{
+		`21년 3월 1일`:     `2021-03-01`,
+		`'21년 3월 1일`:    `2021-03-01`,
+		`’99년 12월 31일`:  `1999-12-31`,
+		`21.3.1`:        `2021-03-01`,
+		`41.1.1`:        `2041-01-01`,
+		`42.1.1`:        `1942-01-01`,
+		`2021.03.01`:    `2021-03-01`,
+		`81년생입니다`:       `1981-01-01`,
+		`'95년생`:         `1995-01-01`,
+		`1981년생`:        `1981-01-01`,
+		`음력 20년 8월 15일`: `2020-10-01`,
+		`20년 추석`:        `2020-10-01`,
+	} {
+		if date, err := ExtractDate(str, true, WithReferenceTime(ref)); err == nil {
+			if date.Format("2006-01-02") != expected {
+				t.Errorf("ExtractDate extracted: %s from string: '%s' (expected: %s)", date.Format("2006-01-02"), str, expected)
+			}
+		} else {
+			t.Errorf("ExtractDate failed with string: '%s' (error: %s)", str, err)
+		}
+	}
+
+	// century window
+	for _, test := range []struct {
+		str      string
+		window   int
+		expected string
+	}{
+		{`30년 1월 1일`, 20, `2030-01-01`},
+		{`30년 1월 1일`, 0, `1930-01-01`},
+		{`21년 1월 1일`, 0, `2021-01-01`},
+		{`35년생`, 50, `1935-01-01`}, // birth years are not in the future
+	} {
+		if date, err := ExtractDate(test.str, true, WithReferenceTime(ref), WithCenturyWindow(test.window)); err != nil || date.Format("2006-01-02") != test.expected {
+			t.Errorf("ExtractDate extracted: %s from string: '%s' with window: %d (expected: %s, error: %v)", date.Format("2006-01-02"), test.str, test.window, test.expected, err)
+		}
+	}
+
+	// matched text
+	if matches, err := ExtractDateMatches(`'81년생 김씨`, true, WithReferenceTime(ref)); err != nil || matches[0].Text != `'81년생` {
+		t.Errorf("ExtractDateMatches returned: %+v (error: %v)", matches, err)
+	}
+}